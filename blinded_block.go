@@ -0,0 +1,476 @@
+package beaconclient
+
+import (
+	"fmt"
+
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// Blinded blocks, defined for the forks since Deneb, carry the execution payload header instead of the payload, they are
+// signed by the proposer and published with PublishBlindedBlock when using an external builder.
+
+// VersionedSignedBlindedBeaconBlock is a signed blinded beacon block of any fork since Deneb
+// Exactly one of the fork fields, matching Version, is set
+type VersionedSignedBlindedBeaconBlock struct {
+	Version ConsensusVersion
+	Deneb   *DenebSignedBlindedBeaconBlock
+	Electra *ElectraSignedBlindedBeaconBlock
+	Fulu    *FuluSignedBlindedBeaconBlock
+}
+
+// signedBlock returns the signed blinded block of the version's fork
+func (b *VersionedSignedBlindedBeaconBlock) signedBlock() (zrntcommon.SpecObj, error) {
+	var block zrntcommon.SpecObj
+	switch b.Version {
+	case ConsensusVersionDeneb:
+		if b.Deneb != nil {
+			block = b.Deneb
+		}
+	case ConsensusVersionElectra:
+		if b.Electra != nil {
+			block = b.Electra
+		}
+	case ConsensusVersionFulu:
+		if b.Fulu != nil {
+			block = b.Fulu
+		}
+	default:
+		return nil, fmt.Errorf("unsupported consensus version for blinded blocks: %s", b.Version)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("missing %s blinded block", b.Version)
+	}
+	return block, nil
+}
+
+// DenebSignedBlindedBeaconBlock is the Deneb SignedBlindedBeaconBlock container
+type DenebSignedBlindedBeaconBlock struct {
+	Message   DenebBlindedBeaconBlock `json:"message" yaml:"message"`
+	Signature zrntcommon.BLSSignature `json:"signature" yaml:"signature"`
+}
+
+func (b *DenebSignedBlindedBeaconBlock) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *DenebSignedBlindedBeaconBlock) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *DenebSignedBlindedBeaconBlock) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *DenebSignedBlindedBeaconBlock) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *DenebSignedBlindedBeaconBlock) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(spec.Wrap(&b.Message), b.Signature)
+}
+
+// DenebBlindedBeaconBlock is the Deneb BlindedBeaconBlock container
+// Its hash tree root equals the root of the full block
+type DenebBlindedBeaconBlock struct {
+	Slot          zrntcommon.Slot             `json:"slot" yaml:"slot"`
+	ProposerIndex zrntcommon.ValidatorIndex   `json:"proposer_index" yaml:"proposer_index"`
+	ParentRoot    zrntcommon.Root             `json:"parent_root" yaml:"parent_root"`
+	StateRoot     zrntcommon.Root             `json:"state_root" yaml:"state_root"`
+	Body          DenebBlindedBeaconBlockBody `json:"body" yaml:"body"`
+}
+
+func (b *DenebBlindedBeaconBlock) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *DenebBlindedBeaconBlock) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *DenebBlindedBeaconBlock) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *DenebBlindedBeaconBlock) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *DenebBlindedBeaconBlock) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, spec.Wrap(&b.Body))
+}
+
+// Header returns the header of the block
+func (b *DenebBlindedBeaconBlock) Header(spec *zrntcommon.Spec) *zrntcommon.BeaconBlockHeader {
+	return &zrntcommon.BeaconBlockHeader{
+		Slot:          b.Slot,
+		ProposerIndex: b.ProposerIndex,
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+		BodyRoot:      b.Body.HashTreeRoot(spec, tree.GetHashFn()),
+	}
+}
+
+// DenebBlindedBeaconBlockBody is the Deneb BlindedBeaconBlockBody container
+// The execution payload is replaced by its header
+type DenebBlindedBeaconBlockBody struct {
+	RandaoReveal zrntcommon.BLSSignature `json:"randao_reveal" yaml:"randao_reveal"`
+	Eth1Data     zrntcommon.Eth1Data     `json:"eth1_data" yaml:"eth1_data"`
+	Graffiti     zrntcommon.Root         `json:"graffiti" yaml:"graffiti"`
+
+	ProposerSlashings phase0.ProposerSlashings `json:"proposer_slashings" yaml:"proposer_slashings"`
+	AttesterSlashings phase0.AttesterSlashings `json:"attester_slashings" yaml:"attester_slashings"`
+	Attestations      phase0.Attestations      `json:"attestations" yaml:"attestations"`
+	Deposits          phase0.Deposits          `json:"deposits" yaml:"deposits"`
+	VoluntaryExits    phase0.VoluntaryExits    `json:"voluntary_exits" yaml:"voluntary_exits"`
+	SyncAggregate     altair.SyncAggregate     `json:"sync_aggregate" yaml:"sync_aggregate"`
+
+	ExecutionPayloadHeader deneb.ExecutionPayloadHeader           `json:"execution_payload_header" yaml:"execution_payload_header"`
+	BLSToExecutionChanges  zrntcommon.SignedBLSToExecutionChanges `json:"bls_to_execution_changes" yaml:"bls_to_execution_changes"`
+	BlobKZGCommitments     deneb.KZGCommitments                   `json:"blob_kzg_commitments" yaml:"blob_kzg_commitments"`
+}
+
+func (b *DenebBlindedBeaconBlockBody) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+	)
+}
+
+func (b *DenebBlindedBeaconBlockBody) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+	)
+}
+
+func (b *DenebBlindedBeaconBlockBody) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+	)
+}
+
+func (b *DenebBlindedBeaconBlockBody) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *DenebBlindedBeaconBlockBody) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(
+		b.RandaoReveal, &b.Eth1Data,
+		b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+	)
+}
+
+// ElectraSignedBlindedBeaconBlock is the Electra SignedBlindedBeaconBlock container
+type ElectraSignedBlindedBeaconBlock struct {
+	Message   ElectraBlindedBeaconBlock `json:"message" yaml:"message"`
+	Signature zrntcommon.BLSSignature   `json:"signature" yaml:"signature"`
+}
+
+func (b *ElectraSignedBlindedBeaconBlock) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *ElectraSignedBlindedBeaconBlock) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *ElectraSignedBlindedBeaconBlock) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *ElectraSignedBlindedBeaconBlock) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *ElectraSignedBlindedBeaconBlock) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(spec.Wrap(&b.Message), b.Signature)
+}
+
+// ElectraBlindedBeaconBlock is the Electra BlindedBeaconBlock container
+// Its hash tree root equals the root of the full block
+type ElectraBlindedBeaconBlock struct {
+	Slot          zrntcommon.Slot               `json:"slot" yaml:"slot"`
+	ProposerIndex zrntcommon.ValidatorIndex     `json:"proposer_index" yaml:"proposer_index"`
+	ParentRoot    zrntcommon.Root               `json:"parent_root" yaml:"parent_root"`
+	StateRoot     zrntcommon.Root               `json:"state_root" yaml:"state_root"`
+	Body          ElectraBlindedBeaconBlockBody `json:"body" yaml:"body"`
+}
+
+func (b *ElectraBlindedBeaconBlock) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *ElectraBlindedBeaconBlock) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *ElectraBlindedBeaconBlock) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *ElectraBlindedBeaconBlock) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *ElectraBlindedBeaconBlock) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, spec.Wrap(&b.Body))
+}
+
+// Header returns the header of the block
+func (b *ElectraBlindedBeaconBlock) Header(spec *zrntcommon.Spec) *zrntcommon.BeaconBlockHeader {
+	return &zrntcommon.BeaconBlockHeader{
+		Slot:          b.Slot,
+		ProposerIndex: b.ProposerIndex,
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+		BodyRoot:      b.Body.HashTreeRoot(spec, tree.GetHashFn()),
+	}
+}
+
+// ElectraBlindedBeaconBlockBody is the Electra BlindedBeaconBlockBody container
+// The execution payload is replaced by its header
+type ElectraBlindedBeaconBlockBody struct {
+	RandaoReveal zrntcommon.BLSSignature `json:"randao_reveal" yaml:"randao_reveal"`
+	Eth1Data     zrntcommon.Eth1Data     `json:"eth1_data" yaml:"eth1_data"`
+	Graffiti     zrntcommon.Root         `json:"graffiti" yaml:"graffiti"`
+
+	ProposerSlashings phase0.ProposerSlashings  `json:"proposer_slashings" yaml:"proposer_slashings"`
+	AttesterSlashings electra.AttesterSlashings `json:"attester_slashings" yaml:"attester_slashings"`
+	Attestations      electra.Attestations      `json:"attestations" yaml:"attestations"`
+	Deposits          phase0.Deposits           `json:"deposits" yaml:"deposits"`
+	VoluntaryExits    phase0.VoluntaryExits     `json:"voluntary_exits" yaml:"voluntary_exits"`
+	SyncAggregate     altair.SyncAggregate      `json:"sync_aggregate" yaml:"sync_aggregate"`
+
+	ExecutionPayloadHeader deneb.ExecutionPayloadHeader           `json:"execution_payload_header" yaml:"execution_payload_header"`
+	BLSToExecutionChanges  zrntcommon.SignedBLSToExecutionChanges `json:"bls_to_execution_changes" yaml:"bls_to_execution_changes"`
+	BlobKZGCommitments     deneb.KZGCommitments                   `json:"blob_kzg_commitments" yaml:"blob_kzg_commitments"`
+	ExecutionRequests      electra.ExecutionRequests              `json:"execution_requests" yaml:"execution_requests"`
+}
+
+func (b *ElectraBlindedBeaconBlockBody) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *ElectraBlindedBeaconBlockBody) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *ElectraBlindedBeaconBlockBody) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *ElectraBlindedBeaconBlockBody) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *ElectraBlindedBeaconBlockBody) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(
+		b.RandaoReveal, &b.Eth1Data,
+		b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+// Like the full Fulu blocks, the Fulu blinded blocks have the Electra fields in a dedicated type.
+
+// FuluSignedBlindedBeaconBlock is the Fulu SignedBlindedBeaconBlock container
+type FuluSignedBlindedBeaconBlock struct {
+	Message   FuluBlindedBeaconBlock  `json:"message" yaml:"message"`
+	Signature zrntcommon.BLSSignature `json:"signature" yaml:"signature"`
+}
+
+func (b *FuluSignedBlindedBeaconBlock) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *FuluSignedBlindedBeaconBlock) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *FuluSignedBlindedBeaconBlock) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *FuluSignedBlindedBeaconBlock) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *FuluSignedBlindedBeaconBlock) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(spec.Wrap(&b.Message), b.Signature)
+}
+
+// FuluBlindedBeaconBlock is the Fulu BlindedBeaconBlock container
+// Its hash tree root equals the root of the full block
+type FuluBlindedBeaconBlock struct {
+	Slot          zrntcommon.Slot            `json:"slot" yaml:"slot"`
+	ProposerIndex zrntcommon.ValidatorIndex  `json:"proposer_index" yaml:"proposer_index"`
+	ParentRoot    zrntcommon.Root            `json:"parent_root" yaml:"parent_root"`
+	StateRoot     zrntcommon.Root            `json:"state_root" yaml:"state_root"`
+	Body          FuluBlindedBeaconBlockBody `json:"body" yaml:"body"`
+}
+
+func (b *FuluBlindedBeaconBlock) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *FuluBlindedBeaconBlock) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *FuluBlindedBeaconBlock) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *FuluBlindedBeaconBlock) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *FuluBlindedBeaconBlock) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, spec.Wrap(&b.Body))
+}
+
+// Header returns the header of the block
+func (b *FuluBlindedBeaconBlock) Header(spec *zrntcommon.Spec) *zrntcommon.BeaconBlockHeader {
+	return &zrntcommon.BeaconBlockHeader{
+		Slot:          b.Slot,
+		ProposerIndex: b.ProposerIndex,
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+		BodyRoot:      b.Body.HashTreeRoot(spec, tree.GetHashFn()),
+	}
+}
+
+// FuluBlindedBeaconBlockBody is the Fulu BlindedBeaconBlockBody container
+// The execution payload is replaced by its header
+type FuluBlindedBeaconBlockBody struct {
+	RandaoReveal zrntcommon.BLSSignature `json:"randao_reveal" yaml:"randao_reveal"`
+	Eth1Data     zrntcommon.Eth1Data     `json:"eth1_data" yaml:"eth1_data"`
+	Graffiti     zrntcommon.Root         `json:"graffiti" yaml:"graffiti"`
+
+	ProposerSlashings phase0.ProposerSlashings  `json:"proposer_slashings" yaml:"proposer_slashings"`
+	AttesterSlashings electra.AttesterSlashings `json:"attester_slashings" yaml:"attester_slashings"`
+	Attestations      electra.Attestations      `json:"attestations" yaml:"attestations"`
+	Deposits          phase0.Deposits           `json:"deposits" yaml:"deposits"`
+	VoluntaryExits    phase0.VoluntaryExits     `json:"voluntary_exits" yaml:"voluntary_exits"`
+	SyncAggregate     altair.SyncAggregate      `json:"sync_aggregate" yaml:"sync_aggregate"`
+
+	ExecutionPayloadHeader deneb.ExecutionPayloadHeader           `json:"execution_payload_header" yaml:"execution_payload_header"`
+	BLSToExecutionChanges  zrntcommon.SignedBLSToExecutionChanges `json:"bls_to_execution_changes" yaml:"bls_to_execution_changes"`
+	BlobKZGCommitments     deneb.KZGCommitments                   `json:"blob_kzg_commitments" yaml:"blob_kzg_commitments"`
+	ExecutionRequests      electra.ExecutionRequests              `json:"execution_requests" yaml:"execution_requests"`
+}
+
+func (b *FuluBlindedBeaconBlockBody) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *FuluBlindedBeaconBlockBody) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *FuluBlindedBeaconBlockBody) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *FuluBlindedBeaconBlockBody) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *FuluBlindedBeaconBlockBody) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(
+		b.RandaoReveal, &b.Eth1Data,
+		b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), &b.ExecutionPayloadHeader,
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
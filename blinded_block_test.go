@@ -0,0 +1,139 @@
+package beaconclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// blindFuluBlock replaces the execution payload of the Fulu testdata block with its header
+func blindFuluBlock(t *testing.T) (*FuluSignedBeaconBlock, *FuluSignedBlindedBeaconBlock) {
+	t.Helper()
+	signed, _ := loadFuluBlock(t)
+	body := &signed.Message.Body
+	payload := &body.ExecutionPayload
+	hFn := tree.GetHashFn()
+
+	return signed, &FuluSignedBlindedBeaconBlock{
+		Message: FuluBlindedBeaconBlock{
+			Slot:          signed.Message.Slot,
+			ProposerIndex: signed.Message.ProposerIndex,
+			ParentRoot:    signed.Message.ParentRoot,
+			StateRoot:     signed.Message.StateRoot,
+			Body: FuluBlindedBeaconBlockBody{
+				RandaoReveal:      body.RandaoReveal,
+				Eth1Data:          body.Eth1Data,
+				Graffiti:          body.Graffiti,
+				ProposerSlashings: body.ProposerSlashings,
+				AttesterSlashings: body.AttesterSlashings,
+				Attestations:      body.Attestations,
+				Deposits:          body.Deposits,
+				VoluntaryExits:    body.VoluntaryExits,
+				SyncAggregate:     body.SyncAggregate,
+				ExecutionPayloadHeader: deneb.ExecutionPayloadHeader{
+					ParentHash:       payload.ParentHash,
+					FeeRecipient:     payload.FeeRecipient,
+					StateRoot:        payload.StateRoot,
+					ReceiptsRoot:     payload.ReceiptsRoot,
+					LogsBloom:        payload.LogsBloom,
+					PrevRandao:       payload.PrevRandao,
+					BlockNumber:      payload.BlockNumber,
+					GasLimit:         payload.GasLimit,
+					GasUsed:          payload.GasUsed,
+					Timestamp:        payload.Timestamp,
+					ExtraData:        payload.ExtraData,
+					BaseFeePerGas:    payload.BaseFeePerGas,
+					BlockHash:        payload.BlockHash,
+					TransactionsRoot: payload.Transactions.HashTreeRoot(configs.Mainnet, hFn),
+					WithdrawalsRoot:  payload.Withdrawals.HashTreeRoot(configs.Mainnet, hFn),
+					BlobGasUsed:      payload.BlobGasUsed,
+					ExcessBlobGas:    payload.ExcessBlobGas,
+				},
+				BLSToExecutionChanges: body.BLSToExecutionChanges,
+				BlobKZGCommitments:    body.BlobKZGCommitments,
+				ExecutionRequests:     body.ExecutionRequests,
+			},
+		},
+		Signature: signed.Signature,
+	}
+}
+
+func TestBlindedBeaconBlock_HashTreeRoot(t *testing.T) {
+	signed, blinded := blindFuluBlock(t)
+	hFn := tree.GetHashFn()
+
+	// the proposer signs the same root for the blinded and the full block
+	if blinded.Message.HashTreeRoot(configs.Mainnet, hFn) != signed.Message.HashTreeRoot(configs.Mainnet, hFn) {
+		t.Error("expected the blinded block root to match the full block root")
+	}
+	if *blinded.Message.Header(configs.Mainnet) != *signed.Message.Header(configs.Mainnet) {
+		t.Error("expected the blinded block header to match the full block header")
+	}
+}
+
+func TestBlindedBeaconBlock_SSZ(t *testing.T) {
+	_, blinded := blindFuluBlock(t)
+
+	var buf bytes.Buffer
+	if err := configs.Mainnet.Wrap(blinded).Serialize(codec.NewEncodingWriter(&buf)); err != nil {
+		t.Fatalf("failed to encode blinded block: %v", err)
+	}
+	if uint64(buf.Len()) != blinded.ByteLength(configs.Mainnet) {
+		t.Errorf("expected %d bytes, got %d", blinded.ByteLength(configs.Mainnet), buf.Len())
+	}
+
+	var decoded FuluSignedBlindedBeaconBlock
+	dr := codec.NewDecodingReader(bytes.NewReader(buf.Bytes()), uint64(buf.Len()))
+	if err := configs.Mainnet.Wrap(&decoded).Deserialize(dr); err != nil {
+		t.Fatalf("failed to decode blinded block: %v", err)
+	}
+	hFn := tree.GetHashFn()
+	if decoded.HashTreeRoot(configs.Mainnet, hFn) != blinded.HashTreeRoot(configs.Mainnet, hFn) {
+		t.Error("decoded blinded block root does not match")
+	}
+
+	data, err := json.Marshal(blinded)
+	if err != nil {
+		t.Fatalf("failed to encode blinded block: %v", err)
+	}
+	var fromJSON FuluSignedBlindedBeaconBlock
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatalf("failed to decode blinded block: %v", err)
+	}
+	if fromJSON.HashTreeRoot(configs.Mainnet, hFn) != blinded.HashTreeRoot(configs.Mainnet, hFn) {
+		t.Error("JSON round trip changed the blinded block")
+	}
+}
+
+func TestVersionedSignedBlindedBeaconBlock_SignedBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   VersionedSignedBlindedBeaconBlock
+		wantErr bool
+	}{
+		{name: "deneb", block: VersionedSignedBlindedBeaconBlock{Version: ConsensusVersionDeneb, Deneb: &DenebSignedBlindedBeaconBlock{}}},
+		{name: "electra", block: VersionedSignedBlindedBeaconBlock{Version: ConsensusVersionElectra, Electra: &ElectraSignedBlindedBeaconBlock{}}},
+		{name: "fulu", block: VersionedSignedBlindedBeaconBlock{Version: ConsensusVersionFulu, Fulu: &FuluSignedBlindedBeaconBlock{}}},
+		{name: "missing block", block: VersionedSignedBlindedBeaconBlock{Version: ConsensusVersionElectra, Fulu: &FuluSignedBlindedBeaconBlock{}}, wantErr: true},
+		{name: "capella", block: VersionedSignedBlindedBeaconBlock{Version: ConsensusVersionCapella}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, err := tt.block.signedBlock()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil || signed == nil {
+				t.Errorf("unexpected result: %v, %v", signed, err)
+			}
+		})
+	}
+}
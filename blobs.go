@@ -3,8 +3,10 @@ package beaconclient
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -22,6 +24,9 @@ type BlobsData struct {
 //
 // block_id can be: "head", "genesis", "finalized", <slot>, <hex encoded blockRoot with 0x prefix>
 // versionedHashes is optional - if provided, only blobs for specified versioned hashes are returned
+//
+// With WithBlobVerification the blobs are checked against versionedHashes, see VerifyBlobs.
+// Without versionedHashes that only checks that the blobs are well formed.
 func (c *Client) GetBlobs(ctx context.Context, blockID string, versionedHashes ...common.Hash) (*BlobsData, error) {
 	var query url.Values
 	if len(versionedHashes) > 0 {
@@ -31,15 +36,118 @@ func (c *Client) GetBlobs(ctx context.Context, blockID string, versionedHashes .
 		}
 	}
 
-	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/blobs/"+blockID, query)
+	var blobs *BlobsData
+	if c.preferSSZ {
+		resp, err := c.doSSZRequest(ctx, http.MethodGet, "/eth/v1/beacon/blobs/"+blockID, query)
+		if err != nil {
+			return nil, err
+		}
+		if resp.isSSZ() {
+			blobs, err = decodeBlobsSSZ(resp)
+		} else {
+			blobs, err = decodeBlobsJSON(resp.body)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/blobs/"+blockID, query)
+		if err != nil {
+			return nil, err
+		}
+		if blobs, err = decodeBlobsJSON(body); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.verifyBlobs {
+		if err := VerifyBlobs(blobs.Data, versionedHashes...); err != nil {
+			return nil, err
+		}
+	}
+	return blobs, nil
+}
+
+func decodeBlobsJSON(body []byte) (*BlobsData, error) {
+	var resp BlobsData
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// decodeBlobsSSZ decodes a List[Blob, MAX_BLOB_COMMITMENTS_PER_BLOCK]
+// Blobs have a fixed size, so the list is a plain concatenation of blobs
+func decodeBlobsSSZ(resp *response) (*BlobsData, error) {
+	blobSize := len(kzg4844.Blob{})
+	if len(resp.body)%blobSize != 0 {
+		return nil, fmt.Errorf("invalid SSZ blobs length %d", len(resp.body))
+	}
+
+	blobs := make([]kzg4844.Blob, len(resp.body)/blobSize)
+	for i := range blobs {
+		copy(blobs[i][:], resp.body[i*blobSize:])
+	}
+	return &BlobsData{
+		ExecutionOptimistic: resp.boolHeader("Eth-Execution-Optimistic"),
+		Finalized:           resp.boolHeader("Eth-Finalized"),
+		Data:                blobs,
+	}, nil
+}
+
+// BlobSidecar represents a blob together with the data required to verify it
+type BlobSidecar struct {
+	// Index is the index of the blob in the block
+	Index uint64 `json:"index,string"`
+	// Blob is the blob data
+	Blob kzg4844.Blob `json:"blob"`
+	// KZGCommitment is the KZG commitment to the blob
+	KZGCommitment kzg4844.Commitment `json:"kzg_commitment"`
+	// KZGProof is the KZG proof for the blob and its commitment
+	KZGProof kzg4844.Proof `json:"kzg_proof"`
+	// SignedBlockHeader is the signed header of the block the blob belongs to
+	SignedBlockHeader SignedBeaconBlockHeader `json:"signed_block_header"`
+	// KZGCommitmentInclusionProof is the Merkle proof of the commitment against the block body root
+	KZGCommitmentInclusionProof []common.Hash `json:"kzg_commitment_inclusion_proof"`
+}
+
+// BlobSidecarsResponse represents the response from /eth/v1/beacon/blob_sidecars/{block_id}
+type BlobSidecarsResponse struct {
+	ExecutionOptimistic bool          `json:"execution_optimistic"`
+	Finalized           bool          `json:"finalized"`
+	Data                []BlobSidecar `json:"data"`
+}
+
+// GetBlobSidecars retrieves blob sidecars for a given block id
+// Endpoint: GET /eth/v1/beacon/blob_sidecars/{block_id}
+//
+// block_id can be: "head", "genesis", "finalized", <slot>, <hex encoded blockRoot with 0x prefix>
+// indices is optional - if provided, only sidecars for the specified blob indices are returned
+//
+// With WithBlobVerification the commitments and proofs are verified, see VerifyBlobSidecars
+func (c *Client) GetBlobSidecars(ctx context.Context, blockID string, indices ...uint64) (*BlobSidecarsResponse, error) {
+	var query url.Values
+	if len(indices) > 0 {
+		query = url.Values{}
+		for _, index := range indices {
+			query.Add("indices", strconv.FormatUint(index, 10))
+		}
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/blob_sidecars/"+blockID, query)
 	if err != nil {
 		return nil, err
 	}
 
-	var resp BlobsData
+	var resp BlobSidecarsResponse
 	if err := json.Unmarshal(body, &resp); err != nil {
 		return nil, err
 	}
 
+	if c.verifyBlobs {
+		if err := VerifyBlobSidecars(resp.Data); err != nil {
+			return nil, err
+		}
+	}
 	return &resp, nil
 }
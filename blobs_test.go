@@ -3,6 +3,7 @@ package beaconclient
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/tree"
 )
 
 func TestGetBlobs_WithVersionedHashes(t *testing.T) {
@@ -150,3 +153,175 @@ func TestGetBlobs_EmptyBlobs(t *testing.T) {
 		t.Errorf("expected 0 blobs, got %d", len(blobs.Data))
 	}
 }
+
+func TestGetBlobs_SSZ(t *testing.T) {
+	blobs := make([]kzg4844.Blob, 2)
+	blobs[0][0] = 1
+	blobs[1][len(blobs[1])-1] = 2
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != acceptSSZ {
+			t.Errorf("unexpected Accept header: %s", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Eth-Finalized", "true")
+		w.WriteHeader(http.StatusOK)
+		for _, blob := range blobs {
+			_, _ = w.Write(blob[:])
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithSSZ())
+	resp, err := client.GetBlobs(context.Background(), "head")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.Finalized {
+		t.Error("expected finalized true")
+	}
+	if len(resp.Data) != len(blobs) {
+		t.Fatalf("expected %d blobs, got %d", len(blobs), len(resp.Data))
+	}
+	for i := range blobs {
+		if resp.Data[i] != blobs[i] {
+			t.Errorf("blob %d does not match", i)
+		}
+	}
+}
+
+func TestGetBlobs_SSZInvalidLength(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte{1, 2, 3})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithSSZ())
+	if _, err := client.GetBlobs(context.Background(), "head"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+// loadTestBlobs returns the blobs of testdata/blob_success.json
+func loadTestBlobs(t *testing.T) []kzg4844.Blob {
+	t.Helper()
+	data, err := os.ReadFile("testdata/blob_success.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	var resp BlobsData
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("failed to decode test data: %v", err)
+	}
+	return resp.Data
+}
+
+// newTestBlobSidecars builds blob sidecars of one block with valid commitments and proofs
+// The blob at position i is placed at indices[i] of the block's blob commitments
+func newTestBlobSidecars(t *testing.T, blobs []kzg4844.Blob, indices ...uint64) []BlobSidecar {
+	t.Helper()
+	body := testMerkleTree{}
+	sidecars := make([]BlobSidecar, len(indices))
+	for i, index := range indices {
+		commitment, err := kzg4844.BlobToCommitment(&blobs[i])
+		if err != nil {
+			t.Fatalf("failed to compute commitment: %v", err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(&blobs[i], commitment)
+		if err != nil {
+			t.Fatalf("failed to compute proof: %v", err)
+		}
+		sidecars[i] = BlobSidecar{Index: index, Blob: blobs[i], KZGCommitment: commitment, KZGProof: proof}
+		body[testBlobCommitmentGindex(index)] = common.Hash(zrntcommon.KZGCommitment(commitment).HashTreeRoot(tree.GetHashFn()))
+	}
+
+	header := SignedBeaconBlockHeader{
+		Message: BeaconBlockHeader{Slot: 9388204, ProposerIndex: 100, BodyRoot: body.root()},
+	}
+	for i := range sidecars {
+		sidecars[i].SignedBlockHeader = header
+		sidecars[i].KZGCommitmentInclusionProof = body.branch(testBlobCommitmentGindex(sidecars[i].Index))
+	}
+	return sidecars
+}
+
+// testBlobCommitmentGindex returns the generalized index of blob_kzg_commitments[index] in BeaconBlockBody
+func testBlobCommitmentGindex(index uint64) uint64 {
+	return (blobKZGCommitmentsGindex*2)<<blobKZGCommitmentsDepth | index
+}
+
+func TestGetBlobSidecars_Success(t *testing.T) {
+	blobs := loadTestBlobs(t)
+	sidecars := newTestBlobSidecars(t, blobs, 0, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/blob_sidecars/9388204" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		indices := r.URL.Query()["indices"]
+		if len(indices) != 2 || indices[0] != "0" || indices[1] != "1" {
+			t.Errorf("unexpected indices: %v", indices)
+		}
+
+		data, err := json.Marshal(BlobSidecarsResponse{Finalized: true, Data: sidecars})
+		if err != nil {
+			t.Fatalf("failed to encode sidecars: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetBlobSidecars(context.Background(), "9388204", 0, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.Finalized {
+		t.Error("expected finalized true")
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 sidecars, got %d", len(resp.Data))
+	}
+	for i, sidecar := range resp.Data {
+		if sidecar.Index != uint64(i) {
+			t.Errorf("expected index %d, got %d", i, sidecar.Index)
+		}
+		if sidecar.Blob != blobs[i] {
+			t.Errorf("sidecar %d: blob does not match", i)
+		}
+		if sidecar.KZGCommitment != sidecars[i].KZGCommitment || sidecar.KZGProof != sidecars[i].KZGProof {
+			t.Errorf("sidecar %d: commitment or proof does not match", i)
+		}
+		if sidecar.SignedBlockHeader.Message.Slot != 9388204 {
+			t.Errorf("sidecar %d: unexpected slot %d", i, sidecar.SignedBlockHeader.Message.Slot)
+		}
+		if len(sidecar.KZGCommitmentInclusionProof) != 17 {
+			t.Errorf("sidecar %d: expected 17 inclusion proof nodes, got %d", i, len(sidecar.KZGCommitmentInclusionProof))
+		}
+	}
+}
+
+func TestGetBlobSidecars_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 404, "message": "Block not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.GetBlobSidecars(context.Background(), "999999999")
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Code != 404 {
+		t.Errorf("expected code 404, got %d", apiErr.Code)
+	}
+}
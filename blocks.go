@@ -1,18 +1,24 @@
 package beaconclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"reflect"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/protolambda/zrnt/eth2/beacon/altair"
 	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
 	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
 	"github.com/protolambda/zrnt/eth2/beacon/deneb"
 	"github.com/protolambda/zrnt/eth2/beacon/electra"
 	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/ztyp/codec"
 )
 
 // ConsensusVersion represents the consensus version/fork name
@@ -37,10 +43,28 @@ type BeaconBlockHeader struct {
 	BodyRoot      common.Hash `json:"body_root"`
 }
 
+func (h *BeaconBlockHeader) Deserialize(dr *codec.DecodingReader) error {
+	return dr.FixedLenContainer((*zrntcommon.Slot)(&h.Slot), (*zrntcommon.ValidatorIndex)(&h.ProposerIndex),
+		(*zrntcommon.Root)(&h.ParentRoot), (*zrntcommon.Root)(&h.StateRoot), (*zrntcommon.Root)(&h.BodyRoot))
+}
+
+func (h *BeaconBlockHeader) Serialize(w *codec.EncodingWriter) error {
+	return w.FixedLenContainer((*zrntcommon.Slot)(&h.Slot), (*zrntcommon.ValidatorIndex)(&h.ProposerIndex),
+		(*zrntcommon.Root)(&h.ParentRoot), (*zrntcommon.Root)(&h.StateRoot), (*zrntcommon.Root)(&h.BodyRoot))
+}
+
+func (h *BeaconBlockHeader) ByteLength() uint64 {
+	return h.FixedLength()
+}
+
+func (h *BeaconBlockHeader) FixedLength() uint64 {
+	return 8 + 8 + 32 + 32 + 32
+}
+
 // SignedBeaconBlockHeader represents a signed beacon block header
 type SignedBeaconBlockHeader struct {
-	Message   BeaconBlockHeader `json:"message"`
-	Signature string            `json:"signature"`
+	Message   BeaconBlockHeader       `json:"message"`
+	Signature zrntcommon.BLSSignature `json:"signature"`
 }
 
 // Eth1Data represents the Eth1 data in a beacon block
@@ -67,8 +91,8 @@ type AttestationData struct {
 
 // SignedBeaconBlock represents a signed beacon block
 type SignedBeaconBlock struct {
-	Message   json.RawMessage `json:"message"`
-	Signature string          `json:"signature"`
+	Message   json.RawMessage         `json:"message"`
+	Signature zrntcommon.BLSSignature `json:"signature"`
 }
 
 // BlockResponse represents the response from /eth/v2/beacon/blocks/{block_id}
@@ -83,52 +107,20 @@ type BlockResponse struct {
 	Data SignedBeaconBlock `json:"data"`
 }
 
-// ParseBlock parses the block data into the appropriate beacon block structure based on the version
-func (block *BlockResponse) ParseBlock() (any, error) {
+// ParseBlock parses the block data into the beacon block structure of its version
+func (block *BlockResponse) ParseBlock() (*VersionedBeaconBlock, error) {
 	if block == nil {
 		return nil, fmt.Errorf("block response is nil")
 	}
 
-	switch block.Version {
-	case ConsensusVersionPhase0:
-		var body phase0.BeaconBlock
-		if err := json.Unmarshal(block.Data.Message, &body); err != nil {
-			return nil, err
-		}
-		return &body, nil
-	case ConsensusVersionAltair:
-		var body altair.BeaconBlock
-		if err := json.Unmarshal(block.Data.Message, &body); err != nil {
-			return nil, err
-		}
-		return body, nil
-	case ConsensusVersionBellatrix:
-		var body bellatrix.BeaconBlock
-		if err := json.Unmarshal(block.Data.Message, &body); err != nil {
-			return nil, err
-		}
-		return &body, nil
-	case ConsensusVersionCapella:
-		var body capella.BeaconBlock
-		if err := json.Unmarshal(block.Data.Message, &body); err != nil {
-			return nil, err
-		}
-		return &body, nil
-	case ConsensusVersionDeneb:
-		var body deneb.BeaconBlock
-		if err := json.Unmarshal(block.Data.Message, &body); err != nil {
-			return nil, err
-		}
-		return &body, nil
-	case ConsensusVersionElectra, ConsensusVersionFulu:
-		var body electra.BeaconBlock
-		if err := json.Unmarshal(block.Data.Message, &body); err != nil {
-			return nil, err
-		}
-		return &body, nil
-	default:
-		return nil, fmt.Errorf("unsupported consensus version: %s", block.Version)
+	body, err := newBeaconBlock(block.Version)
+	if err != nil {
+		return nil, err
 	}
+	if err := json.Unmarshal(block.Data.Message, body); err != nil {
+		return nil, err
+	}
+	return newVersionedBeaconBlock(block.Version, body)
 }
 
 // GetBlock retrieves block details for a given block id
@@ -151,6 +143,120 @@ func (c *Client) GetBlock(ctx context.Context, blockID string) (*BlockResponse,
 	return &resp, nil
 }
 
+// SignedBlockResponse represents a decoded signed beacon block with its metadata
+type SignedBlockResponse struct {
+	// Version is the consensus version of the block
+	Version ConsensusVersion
+	// ExecutionOptimistic is true if the response references an unverified execution payload
+	ExecutionOptimistic bool
+	// Finalized is true if the response references the finalized history of the chain
+	Finalized bool
+	// Data is the signed block of the version's fork, e.g. *deneb.SignedBeaconBlock or *FuluSignedBeaconBlock
+	Data zrntcommon.SpecObj
+}
+
+// Block returns the beacon block of the signed block
+func (r *SignedBlockResponse) Block() (*VersionedBeaconBlock, error) {
+	switch signed := r.Data.(type) {
+	case *phase0.SignedBeaconBlock:
+		return newVersionedBeaconBlock(r.Version, &signed.Message)
+	case *altair.SignedBeaconBlock:
+		return newVersionedBeaconBlock(r.Version, &signed.Message)
+	case *bellatrix.SignedBeaconBlock:
+		return newVersionedBeaconBlock(r.Version, &signed.Message)
+	case *capella.SignedBeaconBlock:
+		return newVersionedBeaconBlock(r.Version, &signed.Message)
+	case *deneb.SignedBeaconBlock:
+		return newVersionedBeaconBlock(r.Version, &signed.Message)
+	case *electra.SignedBeaconBlock:
+		return newVersionedBeaconBlock(r.Version, &signed.Message)
+	case *FuluSignedBeaconBlock:
+		return newVersionedBeaconBlock(r.Version, &signed.Message)
+	default:
+		return nil, fmt.Errorf("unexpected signed block type: %T", r.Data)
+	}
+}
+
+// newSignedBeaconBlock returns an empty zrnt signed beacon block for the given version
+func newSignedBeaconBlock(version ConsensusVersion) (zrntcommon.SpecObj, error) {
+	switch version {
+	case ConsensusVersionPhase0:
+		return new(phase0.SignedBeaconBlock), nil
+	case ConsensusVersionAltair:
+		return new(altair.SignedBeaconBlock), nil
+	case ConsensusVersionBellatrix:
+		return new(bellatrix.SignedBeaconBlock), nil
+	case ConsensusVersionCapella:
+		return new(capella.SignedBeaconBlock), nil
+	case ConsensusVersionDeneb:
+		return new(deneb.SignedBeaconBlock), nil
+	case ConsensusVersionElectra:
+		return new(electra.SignedBeaconBlock), nil
+	case ConsensusVersionFulu:
+		return new(FuluSignedBeaconBlock), nil
+	default:
+		return nil, fmt.Errorf("unsupported consensus version: %s", version)
+	}
+}
+
+// GetBlockSSZ retrieves a block for a given block id using SSZ encoding
+// Endpoint: GET /eth/v2/beacon/blocks/{block_id}
+//
+// block_id can be: "head", "genesis", "finalized", <slot>, <hex encoded blockRoot with 0x prefix>
+//
+// The block is requested as application/octet-stream and decoded into the zrnt type of the fork
+// reported in the Eth-Consensus-Version header. The spec (see GetSpec) provides the SSZ list limits.
+func (c *Client) GetBlockSSZ(ctx context.Context, spec *Spec, blockID string) (*SignedBlockResponse, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is required to decode SSZ blocks")
+	}
+
+	resp, err := c.doSSZRequest(ctx, http.MethodGet, "/eth/v2/beacon/blocks/"+blockID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.isSSZ() {
+		var block BlockResponse
+		if err := json.Unmarshal(resp.body, &block); err != nil {
+			return nil, err
+		}
+		signed, err := newSignedBeaconBlock(block.Version)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(block.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, signed); err != nil {
+			return nil, err
+		}
+		return &SignedBlockResponse{
+			Version:             block.Version,
+			ExecutionOptimistic: block.ExecutionOptimistic,
+			Finalized:           block.Finalized,
+			Data:                signed,
+		}, nil
+	}
+
+	version := resp.consensusVersion()
+	signed, err := newSignedBeaconBlock(version)
+	if err != nil {
+		return nil, err
+	}
+	dr := codec.NewDecodingReader(bytes.NewReader(resp.body), uint64(len(resp.body)))
+	if err := spec.Wrap(signed).Deserialize(dr); err != nil {
+		return nil, fmt.Errorf("failed to decode %s block: %w", version, err)
+	}
+	return &SignedBlockResponse{
+		Version:             version,
+		ExecutionOptimistic: resp.boolHeader("Eth-Execution-Optimistic"),
+		Finalized:           resp.boolHeader("Eth-Finalized"),
+		Data:                signed,
+	}, nil
+}
+
 // BlockRootData represents the block root response data
 type BlockRootData struct {
 	Root common.Hash `json:"root"`
@@ -210,3 +316,251 @@ func (c *Client) GetBlockHeader(ctx context.Context, blockID string) (*BlockHead
 	}
 	return &resp, nil
 }
+
+// BroadcastValidation is the validation a node performs on a published block before broadcasting it
+type BroadcastValidation string
+
+const (
+	// BroadcastValidationGossip only performs the gossip checks, which is the default of nodes
+	BroadcastValidationGossip BroadcastValidation = "gossip"
+	// BroadcastValidationConsensus additionally applies the block to its pre-state
+	BroadcastValidationConsensus BroadcastValidation = "consensus"
+	// BroadcastValidationConsensusAndEquivocation additionally rejects blocks equivocating a known block of the proposer
+	BroadcastValidationConsensusAndEquivocation BroadcastValidation = "consensus_and_equivocation"
+)
+
+// PublishStatus is the outcome of a block publication accepted by the node
+type PublishStatus int
+
+const (
+	// PublishStatusImported indicates the block passed validation, was broadcast and imported by the node
+	PublishStatusImported PublishStatus = 200
+	// PublishStatusBroadcastOnly indicates the block was broadcast, but failed validation and was not imported
+	PublishStatusBroadcastOnly PublishStatus = 202
+)
+
+// VersionedSignedBlockContents is a signed block to publish together with its blobs
+type VersionedSignedBlockContents struct {
+	Version ConsensusVersion
+	// SignedBlock is the signed block of the version's fork, e.g. *deneb.SignedBeaconBlock or *FuluSignedBeaconBlock
+	SignedBlock zrntcommon.SpecObj
+	// KZGProofs are the proofs of the blobs since Deneb
+	// Since Fulu, these are the cell proofs, kzg4844.CellProofsPerBlob per blob.
+	KZGProofs []kzg4844.Proof
+	// Blobs are the blobs of the block since Deneb
+	Blobs []kzg4844.Blob
+}
+
+// hasBlobs reports whether the contents include blobs and proofs besides the signed block
+// Before Deneb, the signed block is published on its own.
+func (b *VersionedSignedBlockContents) hasBlobs() bool {
+	switch b.Version {
+	case ConsensusVersionDeneb, ConsensusVersionElectra, ConsensusVersionFulu:
+		return true
+	default:
+		return false
+	}
+}
+
+// validate checks the signed block type and the number of proofs against the version
+func (b *VersionedSignedBlockContents) validate() error {
+	expected, err := newSignedBeaconBlock(b.Version)
+	if err != nil {
+		return err
+	}
+	if b.SignedBlock == nil || reflect.TypeOf(b.SignedBlock) != reflect.TypeOf(expected) {
+		return fmt.Errorf("unexpected %s signed block type: %T", b.Version, b.SignedBlock)
+	}
+
+	if !b.hasBlobs() {
+		if len(b.Blobs) > 0 || len(b.KZGProofs) > 0 {
+			return fmt.Errorf("%s blocks have no blobs", b.Version)
+		}
+		return nil
+	}
+	proofsPerBlob := 1
+	if b.Version == ConsensusVersionFulu {
+		proofsPerBlob = kzg4844.CellProofsPerBlob
+	}
+	if len(b.KZGProofs) != len(b.Blobs)*proofsPerBlob {
+		return fmt.Errorf("expected %d KZG proofs for %d %s blobs, got %d", len(b.Blobs)*proofsPerBlob, len(b.Blobs), b.Version, len(b.KZGProofs))
+	}
+	return nil
+}
+
+// signedBlockContentsJSON is the JSON encoding of the SignedBlockContents container
+type signedBlockContentsJSON struct {
+	SignedBlock zrntcommon.SpecObj `json:"signed_block"`
+	KZGProofs   []kzg4844.Proof    `json:"kzg_proofs"`
+	Blobs       []kzg4844.Blob     `json:"blobs"`
+}
+
+// kzgProofsSSZ encodes a List[KZGProof, N], the limit only matters for decoding
+type kzgProofsSSZ []kzg4844.Proof
+
+func (l kzgProofsSSZ) Serialize(w *codec.EncodingWriter) error {
+	for i := range l {
+		if err := w.Write(l[i][:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l kzgProofsSSZ) ByteLength() uint64 {
+	return uint64(len(l) * len(kzg4844.Proof{}))
+}
+
+func (l kzgProofsSSZ) FixedLength() uint64 {
+	return 0
+}
+
+// blobsSSZ encodes a List[Blob, MAX_BLOB_COMMITMENTS_PER_BLOCK], the limit only matters for decoding
+type blobsSSZ []kzg4844.Blob
+
+func (l blobsSSZ) Serialize(w *codec.EncodingWriter) error {
+	for i := range l {
+		if err := w.Write(l[i][:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l blobsSSZ) ByteLength() uint64 {
+	return uint64(len(l) * len(kzg4844.Blob{}))
+}
+
+func (l blobsSSZ) FixedLength() uint64 {
+	return 0
+}
+
+// PublishBlock publishes a signed block and its blobs, the node broadcasts it and imports it if valid
+// Endpoint: POST /eth/v2/beacon/blocks
+//
+// broadcastValidation is optional, nodes default to BroadcastValidationGossip.
+// A block failing the validation is not broadcast and the node answers with an *APIError,
+// unless the node already broadcast it, which is reported as PublishStatusBroadcastOnly.
+func (c *Client) PublishBlock(ctx context.Context, contents *VersionedSignedBlockContents, broadcastValidation BroadcastValidation) (PublishStatus, error) {
+	if contents == nil {
+		return 0, fmt.Errorf("missing signed block")
+	}
+	if err := contents.validate(); err != nil {
+		return 0, err
+	}
+
+	var payload any = contents.SignedBlock
+	if contents.hasBlobs() {
+		// empty lists must be encoded as [] instead of null
+		proofs, blobs := contents.KZGProofs, contents.Blobs
+		if proofs == nil {
+			proofs = []kzg4844.Proof{}
+		}
+		if blobs == nil {
+			blobs = []kzg4844.Blob{}
+		}
+		payload = signedBlockContentsJSON{SignedBlock: contents.SignedBlock, KZGProofs: proofs, Blobs: blobs}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s block: %w", contents.Version, err)
+	}
+	return c.publishBlock(ctx, "/eth/v2/beacon/blocks", contents.Version, broadcastValidation, body, contentTypeJSON)
+}
+
+// PublishBlockSSZ publishes a signed block and its blobs using SSZ encoding, see PublishBlock
+// Endpoint: POST /eth/v2/beacon/blocks
+//
+// The spec (see GetSpec) is required to encode the block.
+func (c *Client) PublishBlockSSZ(ctx context.Context, spec *Spec, contents *VersionedSignedBlockContents, broadcastValidation BroadcastValidation) (PublishStatus, error) {
+	if spec == nil {
+		return 0, fmt.Errorf("spec is required to encode SSZ blocks")
+	}
+	if contents == nil {
+		return 0, fmt.Errorf("missing signed block")
+	}
+	if err := contents.validate(); err != nil {
+		return 0, err
+	}
+
+	var buf bytes.Buffer
+	w := codec.NewEncodingWriter(&buf)
+	var err error
+	if contents.hasBlobs() {
+		err = w.Container(spec.Wrap(contents.SignedBlock), kzgProofsSSZ(contents.KZGProofs), blobsSSZ(contents.Blobs))
+	} else {
+		err = spec.Wrap(contents.SignedBlock).Serialize(w)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s block: %w", contents.Version, err)
+	}
+	return c.publishBlock(ctx, "/eth/v2/beacon/blocks", contents.Version, broadcastValidation, buf.Bytes(), contentTypeSSZ)
+}
+
+// PublishBlindedBlock publishes a signed blinded block, the node retrieves the payload from the builder
+// and broadcasts the full block
+// Endpoint: POST /eth/v2/beacon/blinded_blocks
+//
+// broadcastValidation and the returned status behave as for PublishBlock.
+func (c *Client) PublishBlindedBlock(ctx context.Context, block *VersionedSignedBlindedBeaconBlock, broadcastValidation BroadcastValidation) (PublishStatus, error) {
+	if block == nil {
+		return 0, fmt.Errorf("missing signed blinded block")
+	}
+	signed, err := block.signedBlock()
+	if err != nil {
+		return 0, err
+	}
+	body, err := json.Marshal(signed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s blinded block: %w", block.Version, err)
+	}
+	return c.publishBlock(ctx, "/eth/v2/beacon/blinded_blocks", block.Version, broadcastValidation, body, contentTypeJSON)
+}
+
+// PublishBlindedBlockSSZ publishes a signed blinded block using SSZ encoding, see PublishBlindedBlock
+// Endpoint: POST /eth/v2/beacon/blinded_blocks
+//
+// The spec (see GetSpec) is required to encode the block.
+func (c *Client) PublishBlindedBlockSSZ(ctx context.Context, spec *Spec, block *VersionedSignedBlindedBeaconBlock, broadcastValidation BroadcastValidation) (PublishStatus, error) {
+	if spec == nil {
+		return 0, fmt.Errorf("spec is required to encode SSZ blocks")
+	}
+	if block == nil {
+		return 0, fmt.Errorf("missing signed blinded block")
+	}
+	signed, err := block.signedBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	var buf bytes.Buffer
+	if err := spec.Wrap(signed).Serialize(codec.NewEncodingWriter(&buf)); err != nil {
+		return 0, fmt.Errorf("failed to encode %s blinded block: %w", block.Version, err)
+	}
+	return c.publishBlock(ctx, "/eth/v2/beacon/blinded_blocks", block.Version, broadcastValidation, buf.Bytes(), contentTypeSSZ)
+}
+
+// publishBlock posts an encoded block with its Eth-Consensus-Version header
+func (c *Client) publishBlock(ctx context.Context, endpoint string, version ConsensusVersion, broadcastValidation BroadcastValidation, body []byte, contentType string) (PublishStatus, error) {
+	var query url.Values
+	if broadcastValidation != "" {
+		query = url.Values{"broadcast_validation": {string(broadcastValidation)}}
+	}
+
+	resp, err := c.do(ctx, &request{
+		method:      http.MethodPost,
+		endpoint:    endpoint,
+		query:       query,
+		accept:      contentTypeJSON,
+		body:        body,
+		contentType: contentType,
+		version:     version,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if resp.statusCode == http.StatusAccepted {
+		return PublishStatusBroadcastOnly, nil
+	}
+	return PublishStatusImported, nil
+}
@@ -1,7 +1,12 @@
 package beaconclient
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -9,12 +14,12 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/protolambda/zrnt/eth2/beacon/altair"
-	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
-	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/protolambda/zrnt/eth2/beacon/deneb"
-	"github.com/protolambda/zrnt/eth2/beacon/electra"
 	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/tree"
 )
 
 func TestGetBlock_ByVersion(t *testing.T) {
@@ -25,7 +30,7 @@ func TestGetBlock_ByVersion(t *testing.T) {
 		wantVersion  ConsensusVersion
 		wantSlot     uint64
 		wantProposer uint64
-		extraCheck   func(t *testing.T, block any)
+		extraCheck   func(t *testing.T, block *VersionedBeaconBlock)
 	}{
 		{
 			name:         "fulu",
@@ -34,9 +39,9 @@ func TestGetBlock_ByVersion(t *testing.T) {
 			wantVersion:  ConsensusVersionFulu,
 			wantSlot:     13410020,
 			wantProposer: 1797581,
-			extraCheck: func(t *testing.T, block any) {
-				if _, ok := block.(*electra.BeaconBlock); !ok {
-					t.Errorf("expected *electra.BeaconBlock, got %T", block)
+			extraCheck: func(t *testing.T, block *VersionedBeaconBlock) {
+				if block.Fulu == nil {
+					t.Error("expected fulu block to be set")
 				}
 			},
 		},
@@ -47,9 +52,12 @@ func TestGetBlock_ByVersion(t *testing.T) {
 			wantVersion:  ConsensusVersionElectra,
 			wantSlot:     11982020,
 			wantProposer: 1605697,
-			extraCheck: func(t *testing.T, block any) {
-				if _, ok := block.(*electra.BeaconBlock); !ok {
-					t.Errorf("expected *electra.BeaconBlock, got %T", block)
+			extraCheck: func(t *testing.T, block *VersionedBeaconBlock) {
+				if block.Electra == nil {
+					t.Error("expected electra block to be set")
+				}
+				if attestations := block.Attestations(); len(attestations) == 0 || attestations[0].Electra == nil {
+					t.Error("expected electra attestations")
 				}
 			},
 		},
@@ -60,15 +68,18 @@ func TestGetBlock_ByVersion(t *testing.T) {
 			wantVersion:  ConsensusVersionDeneb,
 			wantSlot:     11511320,
 			wantProposer: 1667419,
-			extraCheck: func(t *testing.T, block any) {
-				denebBlock, ok := block.(*deneb.BeaconBlock)
-				if !ok {
-					t.Errorf("expected *deneb.BeaconBlock, got %T", block)
-					return
+			extraCheck: func(t *testing.T, block *VersionedBeaconBlock) {
+				if block.Deneb == nil {
+					t.Error("expected deneb block to be set")
 				}
-				if len(denebBlock.Body.BlobKZGCommitments) != 5 {
+				commitments, ok := block.BlobKZGCommitments()
+				if !ok || len(commitments) != 5 {
 					t.Errorf("BlobKZGCommitments length is not 5")
 				}
+				payload, ok := block.ExecutionPayload()
+				if !ok || payload.Deneb == nil || payload.BlockNumber() == 0 {
+					t.Errorf("expected deneb execution payload, got %+v", payload)
+				}
 			},
 		},
 		{
@@ -78,9 +89,12 @@ func TestGetBlock_ByVersion(t *testing.T) {
 			wantVersion:  ConsensusVersionCapella,
 			wantSlot:     0, // skip slot check
 			wantProposer: 0, // skip proposer check
-			extraCheck: func(t *testing.T, block any) {
-				if _, ok := block.(*capella.BeaconBlock); !ok {
-					t.Errorf("expected *capella.BeaconBlock, got %T", block)
+			extraCheck: func(t *testing.T, block *VersionedBeaconBlock) {
+				if block.Capella == nil {
+					t.Error("expected capella block to be set")
+				}
+				if _, ok := block.BlobKZGCommitments(); ok {
+					t.Error("expected no blob commitments before deneb")
 				}
 			},
 		},
@@ -91,9 +105,12 @@ func TestGetBlock_ByVersion(t *testing.T) {
 			wantVersion:  ConsensusVersionBellatrix,
 			wantSlot:     6155220,
 			wantProposer: 218470,
-			extraCheck: func(t *testing.T, block any) {
-				if _, ok := block.(*bellatrix.BeaconBlock); !ok {
-					t.Errorf("expected *bellatrix.BeaconBlock, got %T", block)
+			extraCheck: func(t *testing.T, block *VersionedBeaconBlock) {
+				if block.Bellatrix == nil {
+					t.Error("expected bellatrix block to be set")
+				}
+				if payload, ok := block.ExecutionPayload(); !ok || payload.Bellatrix == nil {
+					t.Error("expected bellatrix execution payload")
 				}
 			},
 		},
@@ -104,9 +121,9 @@ func TestGetBlock_ByVersion(t *testing.T) {
 			wantVersion:  ConsensusVersionAltair,
 			wantSlot:     3199220,
 			wantProposer: 66269,
-			extraCheck: func(t *testing.T, block any) {
-				if _, ok := block.(altair.BeaconBlock); !ok {
-					t.Errorf("expected altair.BeaconBlock, got %T", block)
+			extraCheck: func(t *testing.T, block *VersionedBeaconBlock) {
+				if block.Altair == nil {
+					t.Error("expected altair block to be set")
 				}
 			},
 		},
@@ -117,9 +134,15 @@ func TestGetBlock_ByVersion(t *testing.T) {
 			wantVersion:  ConsensusVersionPhase0,
 			wantSlot:     1511320,
 			wantProposer: 145572,
-			extraCheck: func(t *testing.T, block any) {
-				if _, ok := block.(*phase0.BeaconBlock); !ok {
-					t.Errorf("expected *phase0.BeaconBlock, got %T", block)
+			extraCheck: func(t *testing.T, block *VersionedBeaconBlock) {
+				if block.Phase0 == nil {
+					t.Error("expected phase0 block to be set")
+				}
+				if _, ok := block.ExecutionPayload(); ok {
+					t.Error("expected no execution payload before bellatrix")
+				}
+				if attestations := block.Attestations(); len(attestations) == 0 || attestations[0].Phase0 == nil {
+					t.Error("expected phase0 attestations")
 				}
 			},
 		},
@@ -167,52 +190,14 @@ func TestGetBlock_ByVersion(t *testing.T) {
 				t.Fatalf("unexpected error parsing block: %v", err)
 			}
 
-			// Check slot and proposer if specified
-			if tt.wantSlot != 0 || tt.wantProposer != 0 {
-				switch b := block.(type) {
-				case *phase0.BeaconBlock:
-					if tt.wantSlot != 0 && uint64(b.Slot) != tt.wantSlot {
-						t.Errorf("slot: got %d, want %d", b.Slot, tt.wantSlot)
-					}
-					if tt.wantProposer != 0 && uint64(b.ProposerIndex) != tt.wantProposer {
-						t.Errorf("proposer_index: got %d, want %d", b.ProposerIndex, tt.wantProposer)
-					}
-				case altair.BeaconBlock:
-					if tt.wantSlot != 0 && uint64(b.Slot) != tt.wantSlot {
-						t.Errorf("slot: got %d, want %d", b.Slot, tt.wantSlot)
-					}
-					if tt.wantProposer != 0 && uint64(b.ProposerIndex) != tt.wantProposer {
-						t.Errorf("proposer_index: got %d, want %d", b.ProposerIndex, tt.wantProposer)
-					}
-				case *bellatrix.BeaconBlock:
-					if tt.wantSlot != 0 && uint64(b.Slot) != tt.wantSlot {
-						t.Errorf("slot: got %d, want %d", b.Slot, tt.wantSlot)
-					}
-					if tt.wantProposer != 0 && uint64(b.ProposerIndex) != tt.wantProposer {
-						t.Errorf("proposer_index: got %d, want %d", b.ProposerIndex, tt.wantProposer)
-					}
-				case *capella.BeaconBlock:
-					if tt.wantSlot != 0 && uint64(b.Slot) != tt.wantSlot {
-						t.Errorf("slot: got %d, want %d", b.Slot, tt.wantSlot)
-					}
-					if tt.wantProposer != 0 && uint64(b.ProposerIndex) != tt.wantProposer {
-						t.Errorf("proposer_index: got %d, want %d", b.ProposerIndex, tt.wantProposer)
-					}
-				case *deneb.BeaconBlock:
-					if tt.wantSlot != 0 && uint64(b.Slot) != tt.wantSlot {
-						t.Errorf("slot: got %d, want %d", b.Slot, tt.wantSlot)
-					}
-					if tt.wantProposer != 0 && uint64(b.ProposerIndex) != tt.wantProposer {
-						t.Errorf("proposer_index: got %d, want %d", b.ProposerIndex, tt.wantProposer)
-					}
-				case *electra.BeaconBlock:
-					if tt.wantSlot != 0 && uint64(b.Slot) != tt.wantSlot {
-						t.Errorf("slot: got %d, want %d", b.Slot, tt.wantSlot)
-					}
-					if tt.wantProposer != 0 && uint64(b.ProposerIndex) != tt.wantProposer {
-						t.Errorf("proposer_index: got %d, want %d", b.ProposerIndex, tt.wantProposer)
-					}
-				}
+			if block.Version != tt.wantVersion {
+				t.Errorf("block version: got %s, want %s", block.Version, tt.wantVersion)
+			}
+			if tt.wantSlot != 0 && block.Slot() != tt.wantSlot {
+				t.Errorf("slot: got %d, want %d", block.Slot(), tt.wantSlot)
+			}
+			if tt.wantProposer != 0 && block.ProposerIndex() != tt.wantProposer {
+				t.Errorf("proposer_index: got %d, want %d", block.ProposerIndex(), tt.wantProposer)
 			}
 
 			if tt.extraCheck != nil {
@@ -497,3 +482,386 @@ func TestParseBlock_Error(t *testing.T) {
 		})
 	}
 }
+
+// loadSignedBlock decodes a testdata block into its zrnt signed block type
+func loadSignedBlock(t *testing.T, testdataFile string) *BlockResponse {
+	t.Helper()
+	data, err := os.ReadFile(testdataFile)
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	var resp BlockResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("failed to decode test data: %v", err)
+	}
+	return &resp
+}
+
+func TestGetBlockSSZ_Success(t *testing.T) {
+	block := loadSignedBlock(t, "testdata/deneb.block.json")
+	data, err := json.Marshal(block.Data)
+	if err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	var want deneb.SignedBeaconBlock
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	var encoded bytes.Buffer
+	if err := configs.Mainnet.Wrap(&want).Serialize(codec.NewEncodingWriter(&encoded)); err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v2/beacon/blocks/11511320" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if !strings.HasPrefix(r.Header.Get("Accept"), "application/octet-stream") {
+			t.Errorf("unexpected Accept header: %s", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Eth-Consensus-Version", "deneb")
+		w.Header().Set("Eth-Execution-Optimistic", "false")
+		w.Header().Set("Eth-Finalized", "true")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(encoded.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetBlockSSZ(context.Background(), &Spec{Spec: *configs.Mainnet}, "11511320")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Version != ConsensusVersionDeneb {
+		t.Errorf("expected version deneb, got %s", resp.Version)
+	}
+	if !resp.Finalized {
+		t.Error("expected finalized true")
+	}
+	got, ok := resp.Data.(*deneb.SignedBeaconBlock)
+	if !ok {
+		t.Fatalf("expected *deneb.SignedBeaconBlock, got %T", resp.Data)
+	}
+	if got.Message.Slot != 11511320 {
+		t.Errorf("expected slot 11511320, got %d", got.Message.Slot)
+	}
+	if got.HashTreeRoot(configs.Mainnet, tree.GetHashFn()) != want.HashTreeRoot(configs.Mainnet, tree.GetHashFn()) {
+		t.Error("decoded block root does not match")
+	}
+
+	versioned, err := resp.Block()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if versioned.Deneb == nil || versioned.ProposerIndex() != 1667419 {
+		t.Errorf("unexpected versioned block: %+v", versioned)
+	}
+}
+
+func TestGetBlockSSZ_JSONFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		data, err := os.ReadFile("testdata/phase0.block.json")
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetBlockSSZ(context.Background(), &Spec{Spec: *configs.Mainnet}, "1511320")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, ok := resp.Data.(*phase0.SignedBeaconBlock)
+	if !ok {
+		t.Fatalf("expected *phase0.SignedBeaconBlock, got %T", resp.Data)
+	}
+	if got.Message.Slot != 1511320 {
+		t.Errorf("expected slot 1511320, got %d", got.Message.Slot)
+	}
+	if !resp.Finalized {
+		t.Error("expected finalized true")
+	}
+}
+
+func TestGetBlockSSZ_UnsupportedVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Eth-Consensus-Version", "unknown_version")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.GetBlockSSZ(context.Background(), &Spec{Spec: *configs.Mainnet}, "head")
+	if err == nil || !strings.Contains(err.Error(), "unsupported consensus version: unknown_version") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// loadDenebSignedBlock decodes testdata/deneb.block.json into its zrnt signed block type
+func loadDenebSignedBlock(t *testing.T) *deneb.SignedBeaconBlock {
+	t.Helper()
+	data, err := json.Marshal(loadSignedBlock(t, "testdata/deneb.block.json").Data)
+	if err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	var signed deneb.SignedBeaconBlock
+	if err := json.Unmarshal(data, &signed); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	return &signed
+}
+
+func TestPublishBlock(t *testing.T) {
+	signed := loadDenebSignedBlock(t)
+	contents := &VersionedSignedBlockContents{
+		Version:     ConsensusVersionDeneb,
+		SignedBlock: signed,
+		KZGProofs:   []kzg4844.Proof{{0x01}},
+		Blobs:       []kzg4844.Blob{{0x02}},
+	}
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v2/beacon/blocks" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("broadcast_validation") != "consensus_and_equivocation" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		if r.Header.Get("Eth-Consensus-Version") != "deneb" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+
+		var body struct {
+			SignedBlock deneb.SignedBeaconBlock `json:"signed_block"`
+			KZGProofs   []kzg4844.Proof         `json:"kzg_proofs"`
+			Blobs       []kzg4844.Blob          `json:"blobs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		if body.SignedBlock.Message.Slot != signed.Message.Slot || len(body.KZGProofs) != 1 || body.Blobs[0][0] != 0x02 {
+			t.Errorf("unexpected block contents: slot %d, %d proofs", body.SignedBlock.Message.Slot, len(body.KZGProofs))
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	got, err := client.PublishBlock(context.Background(), contents, BroadcastValidationConsensusAndEquivocation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != PublishStatusImported {
+		t.Errorf("expected status imported, got %d", got)
+	}
+
+	status = http.StatusAccepted
+	got, err = client.PublishBlock(context.Background(), contents, BroadcastValidationConsensusAndEquivocation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != PublishStatusBroadcastOnly {
+		t.Errorf("expected status broadcast only, got %d", got)
+	}
+}
+
+func TestPublishBlock_Phase0(t *testing.T) {
+	data, err := json.Marshal(loadSignedBlock(t, "testdata/phase0.block.json").Data)
+	if err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	var signed phase0.SignedBeaconBlock
+	if err := json.Unmarshal(data, &signed); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		// before Deneb the signed block is sent without block contents
+		var body phase0.SignedBeaconBlock
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		if body.Message.Slot != 1511320 {
+			t.Errorf("expected slot 1511320, got %d", body.Message.Slot)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	contents := &VersionedSignedBlockContents{Version: ConsensusVersionPhase0, SignedBlock: &signed}
+	if _, err := client.PublishBlock(context.Background(), contents, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPublishBlock_Invalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":400,"message":"Invalid block: signature verification failed"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	signed := loadDenebSignedBlock(t)
+
+	tests := []struct {
+		name     string
+		contents *VersionedSignedBlockContents
+	}{
+		{name: "block of another fork", contents: &VersionedSignedBlockContents{Version: ConsensusVersionElectra, SignedBlock: signed}},
+		{name: "missing block", contents: &VersionedSignedBlockContents{Version: ConsensusVersionDeneb}},
+		{name: "missing proof", contents: &VersionedSignedBlockContents{Version: ConsensusVersionDeneb, SignedBlock: signed, Blobs: make([]kzg4844.Blob, 1)}},
+		{name: "blob proofs after fulu", contents: &VersionedSignedBlockContents{Version: ConsensusVersionFulu, SignedBlock: &FuluSignedBeaconBlock{}, KZGProofs: make([]kzg4844.Proof, 1), Blobs: make([]kzg4844.Blob, 1)}},
+		{name: "blobs before deneb", contents: &VersionedSignedBlockContents{Version: ConsensusVersionPhase0, SignedBlock: &phase0.SignedBeaconBlock{}, Blobs: make([]kzg4844.Blob, 1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.PublishBlock(ctx, tt.contents, ""); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	_, err := client.PublishBlock(ctx, &VersionedSignedBlockContents{Version: ConsensusVersionDeneb, SignedBlock: signed}, BroadcastValidationConsensus)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 400 {
+		t.Errorf("expected *APIError, got %v", err)
+	}
+}
+
+func TestPublishBlock_Nil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	spec := &Spec{Spec: *configs.Mainnet}
+
+	if _, err := client.PublishBlock(ctx, nil, ""); err == nil {
+		t.Error("expected error for a nil block in PublishBlock")
+	}
+	if _, err := client.PublishBlockSSZ(ctx, spec, nil, ""); err == nil {
+		t.Error("expected error for a nil block in PublishBlockSSZ")
+	}
+	if _, err := client.PublishBlindedBlock(ctx, nil, ""); err == nil {
+		t.Error("expected error for a nil block in PublishBlindedBlock")
+	}
+	if _, err := client.PublishBlindedBlockSSZ(ctx, spec, nil, ""); err == nil {
+		t.Error("expected error for a nil block in PublishBlindedBlockSSZ")
+	}
+}
+
+func TestPublishBlockSSZ(t *testing.T) {
+	signed, _ := loadFuluBlock(t)
+	spec := &Spec{Spec: *configs.Mainnet}
+	contents := &VersionedSignedBlockContents{
+		Version:     ConsensusVersionFulu,
+		SignedBlock: signed,
+		KZGProofs:   make([]kzg4844.Proof, kzg4844.CellProofsPerBlob),
+		Blobs:       []kzg4844.Blob{{0x02}},
+	}
+	contents.KZGProofs[0] = kzg4844.Proof{0x01}
+
+	var block bytes.Buffer
+	if err := spec.Wrap(signed).Serialize(codec.NewEncodingWriter(&block)); err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Eth-Consensus-Version") != "fulu" || r.Header.Get("Content-Type") != "application/octet-stream" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read body: %v", err)
+		}
+
+		// SignedBlockContents: three offsets, then the block, the proofs and the blobs
+		proofsOffset := 12 + block.Len()
+		blobsOffset := proofsOffset + kzg4844.CellProofsPerBlob*48
+		if len(body) != blobsOffset+len(kzg4844.Blob{}) {
+			t.Fatalf("unexpected body length %d", len(body))
+		}
+		if binary.LittleEndian.Uint32(body[0:]) != 12 || binary.LittleEndian.Uint32(body[4:]) != uint32(proofsOffset) || binary.LittleEndian.Uint32(body[8:]) != uint32(blobsOffset) {
+			t.Errorf("unexpected offsets: %x", body[:12])
+		}
+		if !bytes.Equal(body[12:proofsOffset], block.Bytes()) || body[proofsOffset] != 0x01 || body[blobsOffset] != 0x02 {
+			t.Error("unexpected block contents")
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	status, err := client.PublishBlockSSZ(context.Background(), spec, contents, BroadcastValidationGossip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != PublishStatusBroadcastOnly {
+		t.Errorf("expected status broadcast only, got %d", status)
+	}
+
+	if _, err := client.PublishBlockSSZ(context.Background(), nil, contents, ""); err == nil {
+		t.Error("expected error without spec")
+	}
+}
+
+func TestPublishBlindedBlock(t *testing.T) {
+	_, blinded := blindFuluBlock(t)
+	block := &VersionedSignedBlindedBeaconBlock{Version: ConsensusVersionFulu, Fulu: blinded}
+	spec := &Spec{Spec: *configs.Mainnet}
+	root := blinded.Message.HashTreeRoot(configs.Mainnet, tree.GetHashFn())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v2/beacon/blinded_blocks" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Eth-Consensus-Version") != "fulu" {
+			t.Errorf("unexpected Eth-Consensus-Version header: %s", r.Header.Get("Eth-Consensus-Version"))
+		}
+
+		var got FuluSignedBlindedBeaconBlock
+		if r.Header.Get("Content-Type") == "application/octet-stream" {
+			body, _ := io.ReadAll(r.Body)
+			dr := codec.NewDecodingReader(bytes.NewReader(body), uint64(len(body)))
+			if err := configs.Mainnet.Wrap(&got).Deserialize(dr); err != nil {
+				t.Errorf("failed to decode body: %v", err)
+			}
+		} else if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		if got.Message.HashTreeRoot(configs.Mainnet, tree.GetHashFn()) != root {
+			t.Error("unexpected blinded block")
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if status, err := client.PublishBlindedBlock(context.Background(), block, BroadcastValidationConsensus); err != nil || status != PublishStatusImported {
+		t.Errorf("unexpected result: %d, %v", status, err)
+	}
+	if status, err := client.PublishBlindedBlockSSZ(context.Background(), spec, block, BroadcastValidationConsensus); err != nil || status != PublishStatusImported {
+		t.Errorf("unexpected SSZ result: %d, %v", status, err)
+	}
+
+	if _, err := client.PublishBlindedBlock(context.Background(), &VersionedSignedBlindedBeaconBlock{Version: ConsensusVersionFulu}, ""); err == nil {
+		t.Error("expected error for a missing blinded block")
+	}
+}
@@ -1,27 +1,112 @@
+// Package beaconclient is a client for the Ethereum beacon node API
+//
+// Responses are decoded into the zrnt types of each fork, containers zrnt does not provide
+// are defined in this package. The SSZ getters that decode into these types also accept JSON
+// from nodes without SSZ support.
 package beaconclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Client is a beacon node API client
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	timeout    time.Duration
+	transport  http.RoundTripper
+	headers    http.Header
+	userAgent  string
+	retry      RetryPolicy
+	preferSSZ  bool
+	// verifyBlobs enables local KZG verification of fetched blobs
+	verifyBlobs bool
+	// pool is set on the client embedded in a MultiClient and routes requests to its nodes
+	pool *nodePool
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the underlying HTTP client used for all requests
+// The given client is copied, so WithTimeout and WithTransport never mutate it
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithTimeout sets the overall timeout of a single HTTP request
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithTransport sets the HTTP transport, e.g. to customize TLS or proxy settings
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithHeader adds a default header sent with every request
+// It can be used multiple times, e.g. for authentication headers
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithSSZ makes endpoints returning large, spec independent data such as GetBlobs
+// request SSZ encoded responses instead of JSON
+func WithSSZ() Option {
+	return func(c *Client) {
+		c.preferSSZ = true
+	}
 }
 
 // NewClient creates a new beacon node API client
-func NewClient(baseURL string) *Client {
-	return &Client{
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
 		httpClient: &http.Client{},
+		headers:    http.Header{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.timeout > 0 || c.transport != nil {
+		httpClient := *c.httpClient
+		if c.timeout > 0 {
+			httpClient.Timeout = c.timeout
+		}
+		if c.transport != nil {
+			httpClient.Transport = c.transport
+		}
+		c.httpClient = &httpClient
 	}
+	return c
 }
 
 // APIError represents an error response from the beacon node API
@@ -34,40 +119,242 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("beacon API error (code %d): %s", e.Code, e.Message)
 }
 
-// doRequest performs an HTTP request and returns the raw response body
-// Each endpoint should define its own response structure and unmarshal accordingly
-func (c *Client) doRequest(ctx context.Context, method, endpoint string, query url.Values) ([]byte, error) {
+// IndexedErrorMessage represents an error response to a batch submission
+// Failures lists the rejected items, the others were accepted by the node.
+// errors.As also matches it as *APIError.
+type IndexedErrorMessage struct {
+	APIError
+	Failures []IndexedError `json:"failures"`
+}
+
+// IndexedError describes why the item at Index of a batch submission was rejected
+type IndexedError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+func (e *IndexedErrorMessage) Error() string {
+	failures := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		failures[i] = fmt.Sprintf("item %d: %s", failure.Index, failure.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.APIError.Error(), strings.Join(failures, "; "))
+}
+
+func (e *IndexedErrorMessage) Unwrap() error {
+	return &e.APIError
+}
+
+const (
+	contentTypeJSON = "application/json"
+	contentTypeSSZ  = "application/octet-stream"
+	// acceptSSZ prefers SSZ but still allows nodes without SSZ support to answer with JSON
+	acceptSSZ = "application/octet-stream;q=1.0,application/json;q=0.9"
+)
+
+// request describes a beacon node API request
+type request struct {
+	method      string
+	endpoint    string
+	query       url.Values
+	accept      string
+	body        []byte
+	contentType string
+	// version is sent as Eth-Consensus-Version header if not empty
+	version ConsensusVersion
+	// idempotent marks requests that may be sent more than once, i.e. retried and failed over,
+	// which includes read-only POST requests such as validator queries
+	idempotent bool
+}
+
+// response is a beacon node API response with its body fully read
+type response struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// isSSZ reports whether the response body is SSZ encoded
+func (r *response) isSSZ() bool {
+	mediaType, _, _ := mime.ParseMediaType(r.header.Get("Content-Type"))
+	return mediaType == contentTypeSSZ
+}
+
+// consensusVersion returns the value of the Eth-Consensus-Version header
+func (r *response) consensusVersion() ConsensusVersion {
+	return ConsensusVersion(r.header.Get("Eth-Consensus-Version"))
+}
+
+// boolHeader parses a boolean metadata header such as Eth-Finalized
+func (r *response) boolHeader(key string) bool {
+	value, _ := strconv.ParseBool(r.header.Get(key))
+	return value
+}
+
+// newRequest creates an HTTP request with the client's default headers applied
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, query url.Values, body []byte) (*http.Request, error) {
 	fullURL := c.baseURL + endpoint
 	if len(query) > 0 {
 		fullURL += "?" + query.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, nil)
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Accept", "application/json")
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	return req, nil
+}
+
+// doRequest performs an HTTP request and returns the raw response body
+// Each endpoint should define its own response structure and unmarshal accordingly
+// Failed attempts are retried according to the client's RetryPolicy
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, query url.Values) ([]byte, error) {
+	resp, err := c.do(ctx, &request{
+		method:     method,
+		endpoint:   endpoint,
+		query:      query,
+		accept:     contentTypeJSON,
+		idempotent: isIdempotent(method),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// doJSONRequest performs an HTTP request with payload encoded as JSON request body
+func (c *Client) doJSONRequest(ctx context.Context, method, endpoint string, query url.Values, payload any) ([]byte, error) {
+	return c.doVersionedJSONRequest(ctx, method, endpoint, query, "", payload)
+}
+
+// doVersionedJSONRequest performs an HTTP request with payload encoded as JSON request body
+// The Eth-Consensus-Version header is set to version, if not empty
+func (c *Client) doVersionedJSONRequest(ctx context.Context, method, endpoint string, query url.Values, version ConsensusVersion, payload any) ([]byte, error) {
+	return c.doPayloadRequest(ctx, &request{method: method, endpoint: endpoint, query: query, version: version}, payload)
+}
+
+// doQueryJSONRequest performs a read-only POST request with payload encoded as JSON request body
+// Unlike submissions, such queries are retried and failed over like GET requests
+func (c *Client) doQueryJSONRequest(ctx context.Context, endpoint string, query url.Values, payload any) ([]byte, error) {
+	return c.doPayloadRequest(ctx, &request{method: http.MethodPost, endpoint: endpoint, query: query, idempotent: true}, payload)
+}
+
+// doPayloadRequest sends req with payload encoded as JSON request body and returns the raw response body
+func (c *Client) doPayloadRequest(ctx context.Context, req *request, payload any) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %w", err)
+	}
+
+	req.accept = contentTypeJSON
+	req.body = body
+	req.contentType = contentTypeJSON
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// doSSZRequest performs an HTTP request preferring an SSZ encoded response
+// Callers must check response.isSSZ since nodes may still answer with JSON
+func (c *Client) doSSZRequest(ctx context.Context, method, endpoint string, query url.Values) (*response, error) {
+	return c.do(ctx, &request{
+		method:     method,
+		endpoint:   endpoint,
+		query:      query,
+		accept:     acceptSSZ,
+		idempotent: isIdempotent(method),
+	})
+}
+
+// do sends the request to the client's node, or to the nodes of its pool
+func (c *Client) do(ctx context.Context, req *request) (*response, error) {
+	if c.pool != nil {
+		return c.pool.do(ctx, req)
+	}
+	resp, err := c.doWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// doWithRetry performs attempts until one succeeds or the RetryPolicy gives up
+// The response of the last attempt is returned alongside its error, see doAttempt
+func (c *Client) doWithRetry(ctx context.Context, req *request) (*response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doAttempt(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil || !c.retry.shouldRetry(req.idempotent, attempt, resp) {
+			return resp, err
+		}
+
+		timer := time.NewTimer(c.retry.backoff(attempt, resp))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+	}
+}
+
+// doAttempt performs a single HTTP round trip
+// The returned response is nil if no complete response was received, even if err is not nil
+func (c *Client) doAttempt(ctx context.Context, req *request) (*response, error) {
+	httpReq, err := c.newRequest(ctx, req.method, req.endpoint, req.query, req.body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", req.accept)
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if req.version != "" {
+		httpReq.Header.Set("Eth-Consensus-Version", string(req.version))
+	}
 
-	resp, err := c.httpClient.Do(req)
+	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	//nolint:errcheck
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(httpResp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		var apiErr APIError
+	resp := &response{statusCode: httpResp.StatusCode, header: httpResp.Header, body: body}
+	// any 2xx is a success, e.g. 202 for published blocks which failed validation
+	if resp.statusCode < http.StatusOK || resp.statusCode >= http.StatusMultipleChoices {
+		var apiErr IndexedErrorMessage
 		if err := json.Unmarshal(body, &apiErr); err != nil {
-			return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+			return resp, fmt.Errorf("unexpected status code %d: %s", resp.statusCode, string(body))
+		}
+		if len(apiErr.Failures) > 0 {
+			return resp, &apiErr
 		}
-		return nil, &apiErr
+		return resp, &apiErr.APIError
 	}
 
-	return body, nil
+	return resp, nil
 }
@@ -3,9 +3,12 @@ package beaconclient
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -28,6 +31,72 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_Options(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("User-Agent") != "indexer/1.0" {
+			t.Errorf("unexpected User-Agent header: %s", r.Header.Get("User-Agent"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{}
+	client := NewClient(server.URL,
+		WithHTTPClient(httpClient),
+		WithTimeout(5*time.Second),
+		WithHeader("Authorization", "Bearer secret"),
+		WithUserAgent("indexer/1.0"),
+	)
+
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("expected timeout 5s, got %s", client.httpClient.Timeout)
+	}
+	if httpClient.Timeout != 0 {
+		t.Errorf("expected provided http client to be left untouched, got timeout %s", httpClient.Timeout)
+	}
+
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/test", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// GetHealth must share the same default headers
+	if _, err := client.GetHealth(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClient_WithTransport(t *testing.T) {
+	var called bool
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		called = true
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithTransport(transport))
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/test", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected custom transport to be used")
+	}
+}
+
 func TestAPIError_Error(t *testing.T) {
 	err := &APIError{Code: 404, Message: "Block not found"}
 	expected := "beacon API error (code 404): Block not found"
@@ -95,6 +164,35 @@ func TestDoRequest_APIError(t *testing.T) {
 	}
 }
 
+func TestDoJSONRequest_IndexedErrorMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code": 400, "message": "some failures", "failures": [{"index": 0, "message": "invalid signature"}, {"index": 2, "message": "unknown validator"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.doJSONRequest(context.Background(), http.MethodPost, "/eth/v1/beacon/pool/sync_committees", nil, []int{1, 2, 3})
+	var indexedErr *IndexedErrorMessage
+	if !errors.As(err, &indexedErr) {
+		t.Fatalf("expected *IndexedErrorMessage, got %T", err)
+	}
+	want := []IndexedError{{Index: 0, Message: "invalid signature"}, {Index: 2, Message: "unknown validator"}}
+	if !reflect.DeepEqual(indexedErr.Failures, want) {
+		t.Errorf("unexpected failures: %+v", indexedErr.Failures)
+	}
+	expected := "beacon API error (code 400): some failures: item 0: invalid signature; item 2: unknown validator"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 400 {
+		t.Errorf("expected the indexed error to match *APIError, got %v", apiErr)
+	}
+}
+
 func TestDoRequest_NotFoundError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,124 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ValidatorIndices is a list of validator indices encoded as decimal strings in JSON
+type ValidatorIndices []uint64
+
+// UnmarshalJSON decodes a JSON array of quoted integers
+func (v *ValidatorIndices) UnmarshalJSON(data []byte) error {
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	indices := make(ValidatorIndices, len(values))
+	for i, value := range values {
+		index, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		indices[i] = index
+	}
+	*v = indices
+	return nil
+}
+
+// MarshalJSON encodes the indices as a JSON array of quoted integers
+func (v ValidatorIndices) MarshalJSON() ([]byte, error) {
+	values := make([]string, len(v))
+	for i, index := range v {
+		values[i] = strconv.FormatUint(index, 10)
+	}
+	return json.Marshal(values)
+}
+
+// BeaconCommittee contains the validators of a beacon committee
+type BeaconCommittee struct {
+	// Index is the committee index at the slot
+	Index uint64 `json:"index,string"`
+	// Slot is the slot the committee is attesting to
+	Slot uint64 `json:"slot,string"`
+	// Validators are the validator indices of the committee members, in committee order
+	Validators ValidatorIndices `json:"validators"`
+}
+
+// CommitteesResponse represents the response from /eth/v1/beacon/states/{state_id}/committees
+type CommitteesResponse struct {
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+	Finalized           bool              `json:"finalized"`
+	Data                []BeaconCommittee `json:"data"`
+}
+
+// GetCommittees retrieves the committees for the given state, optionally filtered by epoch, index and slot
+// Endpoint: GET /eth/v1/beacon/states/{state_id}/committees
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+// nil filters are omitted; the node defaults to the epoch of the state
+func (c *Client) GetCommittees(ctx context.Context, stateID string, epoch, index, slot *uint64) (*CommitteesResponse, error) {
+	query := url.Values{}
+	if epoch != nil {
+		query.Set("epoch", strconv.FormatUint(*epoch, 10))
+	}
+	if index != nil {
+		query.Set("index", strconv.FormatUint(*index, 10))
+	}
+	if slot != nil {
+		query.Set("slot", strconv.FormatUint(*slot, 10))
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/states/"+stateID+"/committees", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CommitteesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SyncCommittee contains the validators of a sync committee
+type SyncCommittee struct {
+	// Validators are the validator indices of the sync committee members, in committee order
+	Validators ValidatorIndices `json:"validators"`
+	// ValidatorAggregates are the validator indices of each sync subcommittee
+	ValidatorAggregates []ValidatorIndices `json:"validator_aggregates"`
+}
+
+// SyncCommitteesResponse represents the response from /eth/v1/beacon/states/{state_id}/sync_committees
+type SyncCommitteesResponse struct {
+	ExecutionOptimistic bool          `json:"execution_optimistic"`
+	Finalized           bool          `json:"finalized"`
+	Data                SyncCommittee `json:"data"`
+}
+
+// GetSyncCommittees retrieves the sync committee for the given state and epoch
+// Endpoint: GET /eth/v1/beacon/states/{state_id}/sync_committees
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+// epoch is optional; the node defaults to the epoch of the state
+func (c *Client) GetSyncCommittees(ctx context.Context, stateID string, epoch *uint64) (*SyncCommitteesResponse, error) {
+	query := url.Values{}
+	if epoch != nil {
+		query.Set("epoch", strconv.FormatUint(*epoch, 10))
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/states/"+stateID+"/sync_committees", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SyncCommitteesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
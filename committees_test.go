@@ -0,0 +1,134 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGetCommittees_WithFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/committees" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("epoch") != "100" {
+			t.Errorf("unexpected epoch filter: %s", query.Get("epoch"))
+		}
+		if query.Get("slot") != "3205" {
+			t.Errorf("unexpected slot filter: %s", query.Get("slot"))
+		}
+		if query.Has("index") {
+			t.Errorf("unexpected index filter: %s", query.Get("index"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": false,
+			"data": [
+				{"index": "0", "slot": "3205", "validators": ["10", "2", "33"]},
+				{"index": "1", "slot": "3205", "validators": ["7"]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	epoch, slot := uint64(100), uint64(3205)
+	client := NewClient(server.URL)
+	resp, err := client.GetCommittees(context.Background(), "head", &epoch, nil, &slot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 committees, got %d", len(resp.Data))
+	}
+	if resp.Data[1].Index != 1 || resp.Data[1].Slot != 3205 {
+		t.Errorf("unexpected committee: %+v", resp.Data[1])
+	}
+	if !slices.Equal(resp.Data[0].Validators, ValidatorIndices{10, 2, 33}) {
+		t.Errorf("unexpected validators: %v", resp.Data[0].Validators)
+	}
+}
+
+func TestGetCommittees_NoFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"execution_optimistic": false, "finalized": true, "data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.GetCommittees(context.Background(), "finalized", nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetSyncCommittees_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/sync_committees" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("epoch") != "256" {
+			t.Errorf("unexpected epoch filter: %s", r.URL.Query().Get("epoch"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": false,
+			"data": {
+				"validators": ["1", "2", "3", "4"],
+				"validator_aggregates": [["1", "2"], ["3", "4"]]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	epoch := uint64(256)
+	client := NewClient(server.URL)
+	resp, err := client.GetSyncCommittees(context.Background(), "head", &epoch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(resp.Data.Validators, ValidatorIndices{1, 2, 3, 4}) {
+		t.Errorf("unexpected validators: %v", resp.Data.Validators)
+	}
+	if len(resp.Data.ValidatorAggregates) != 2 || !slices.Equal(resp.Data.ValidatorAggregates[1], ValidatorIndices{3, 4}) {
+		t.Errorf("unexpected validator_aggregates: %v", resp.Data.ValidatorAggregates)
+	}
+}
+
+func TestValidatorIndices_JSON(t *testing.T) {
+	indices := ValidatorIndices{0, 18446744073709551615}
+	data, err := json.Marshal(indices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `["0","18446744073709551615"]` {
+		t.Errorf("unexpected encoding: %s", data)
+	}
+
+	var decoded ValidatorIndices
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(decoded, indices) {
+		t.Errorf("unexpected decoding: %v", decoded)
+	}
+
+	if err := json.Unmarshal([]byte(`["x"]`), &decoded); err == nil {
+		t.Error("expected error for invalid index")
+	}
+}
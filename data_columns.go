@@ -0,0 +1,193 @@
+package beaconclient
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// NumberOfColumns is the number of columns of the extended blob matrix (NUMBER_OF_COLUMNS)
+const NumberOfColumns = kzg4844.CellsPerBlob
+
+// kzgCommitmentsInclusionProofDepth is KZG_COMMITMENTS_INCLUSION_PROOF_DEPTH, the depth of the body fields
+const kzgCommitmentsInclusionProofDepth = 4
+
+// DataColumnSidecar represents one column of the extended blob matrix of a block
+type DataColumnSidecar struct {
+	// Index is the column index
+	Index uint64 `json:"index,string"`
+	// Column contains the cell of this column for every blob in the block
+	Column []kzg4844.Cell `json:"column"`
+	// KZGCommitments are the commitments of all blobs in the block
+	KZGCommitments []kzg4844.Commitment `json:"kzg_commitments"`
+	// KZGProofs contains the cell proof of every cell in Column
+	KZGProofs []kzg4844.Proof `json:"kzg_proofs"`
+	// SignedBlockHeader is the signed header of the block the column belongs to
+	SignedBlockHeader SignedBeaconBlockHeader `json:"signed_block_header"`
+	// KZGCommitmentsInclusionProof is the Merkle proof of the commitments against the block body root
+	KZGCommitmentsInclusionProof []common.Hash `json:"kzg_commitments_inclusion_proof"`
+}
+
+// DataColumnSidecarsResponse represents the response from /eth/v1/debug/beacon/data_column_sidecars/{block_id}
+type DataColumnSidecarsResponse struct {
+	Version             ConsensusVersion    `json:"version"`
+	ExecutionOptimistic bool                `json:"execution_optimistic"`
+	Finalized           bool                `json:"finalized"`
+	Data                []DataColumnSidecar `json:"data"`
+}
+
+// GetDataColumnSidecars retrieves data column sidecars for a given block id
+// Endpoint: GET /eth/v1/debug/beacon/data_column_sidecars/{block_id}
+//
+// block_id can be: "head", "genesis", "finalized", <slot>, <hex encoded blockRoot with 0x prefix>
+// indices is optional - if provided, only sidecars for the specified column indices are returned
+//
+// With WithBlobVerification the cell proofs and inclusion proofs are verified, see VerifyDataColumnSidecars
+func (c *Client) GetDataColumnSidecars(ctx context.Context, blockID string, indices ...uint64) (*DataColumnSidecarsResponse, error) {
+	var query url.Values
+	if len(indices) > 0 {
+		query = url.Values{}
+		for _, index := range indices {
+			query.Add("indices", strconv.FormatUint(index, 10))
+		}
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/debug/beacon/data_column_sidecars/"+blockID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp DataColumnSidecarsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if c.verifyBlobs {
+		if err := VerifyDataColumnSidecars(resp.Data); err != nil {
+			return nil, err
+		}
+	}
+	return &resp, nil
+}
+
+// VerifyDataColumnSidecars verifies the cell proofs of every column against the blob commitments
+// and the inclusion proof of the commitments against the body root of the block header
+//
+// All sidecars must carry the same block header, which itself is not verified here.
+// Inclusion proofs are checked for the mainnet preset.
+// A *BlobVerificationError is returned for an invalid column, with Index set to the column index
+func VerifyDataColumnSidecars(sidecars []DataColumnSidecar) error {
+	for i := range sidecars {
+		sidecar := &sidecars[i]
+		if sidecar.SignedBlockHeader != sidecars[0].SignedBlockHeader {
+			return &BlobVerificationError{Index: sidecar.Index, Reason: "block header differs from the other sidecars"}
+		}
+		if err := verifyDataColumnSidecar(sidecar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyDataColumnSidecar(sidecar *DataColumnSidecar) error {
+	switch {
+	case sidecar.Index >= NumberOfColumns:
+		return &BlobVerificationError{Index: sidecar.Index, Reason: "column index out of range"}
+	case len(sidecar.Column) != len(sidecar.KZGCommitments) || len(sidecar.Column) != len(sidecar.KZGProofs):
+		return &BlobVerificationError{Index: sidecar.Index, Reason: "mismatched number of cells, commitments and proofs"}
+	case len(sidecar.KZGCommitments) == 0:
+		return &BlobVerificationError{Index: sidecar.Index, Reason: "empty column"}
+	}
+
+	if err := kzg4844.VerifyCells(sidecar.Column, sidecar.KZGCommitments, sidecar.KZGProofs, []uint64{sidecar.Index}); err != nil {
+		return &BlobVerificationError{Index: sidecar.Index, Reason: "invalid cell proofs", Err: err}
+	}
+	if !verifyDataColumnSidecarInclusionProof(sidecar) {
+		return &BlobVerificationError{Index: sidecar.Index, Reason: "invalid commitments inclusion proof"}
+	}
+	return nil
+}
+
+// verifyDataColumnSidecarInclusionProof is equivalent to the Python spec function verify_data_column_sidecar_inclusion_proof
+func verifyDataColumnSidecarInclusionProof(sidecar *DataColumnSidecar) bool {
+	if len(sidecar.KZGCommitments) > maxBlobCommitmentsPerBlock || len(sidecar.KZGCommitmentsInclusionProof) != kzgCommitmentsInclusionProofDepth {
+		return false
+	}
+
+	leaf := kzgCommitmentsRoot(sidecar.KZGCommitments)
+	return IsValidMerkleBranch(leaf, sidecar.KZGCommitmentsInclusionProof, kzgCommitmentsInclusionProofDepth,
+		blobKZGCommitmentsGindex%(1<<kzgCommitmentsInclusionProofDepth), sidecar.SignedBlockHeader.Message.BodyRoot)
+}
+
+// kzgCommitmentsRoot returns the hash tree root of a List[KZGCommitment, MAX_BLOB_COMMITMENTS_PER_BLOCK]
+func kzgCommitmentsRoot(commitments []kzg4844.Commitment) common.Hash {
+	length := uint64(len(commitments))
+	return common.Hash(tree.GetHashFn().ComplexListHTR(func(i uint64) tree.HTR {
+		if i < length {
+			return zrntcommon.KZGCommitment(commitments[i])
+		}
+		return nil
+	}, length, maxBlobCommitmentsPerBlock))
+}
+
+// ReconstructBlobs recovers the blobs of a block from its data column sidecars
+//
+// At least half of the columns (NumberOfColumns / 2) must be given, in any order.
+// The sidecars are expected to belong to the same block, and are not verified here.
+func ReconstructBlobs(sidecars []DataColumnSidecar) ([]kzg4844.Blob, error) {
+	columns := make([]*DataColumnSidecar, 0, len(sidecars))
+	seen := make(map[uint64]bool, len(sidecars))
+	for i := range sidecars {
+		sidecar := &sidecars[i]
+		if sidecar.Index >= NumberOfColumns {
+			return nil, fmt.Errorf("column index %d out of range", sidecar.Index)
+		}
+		if seen[sidecar.Index] {
+			continue
+		}
+		seen[sidecar.Index] = true
+		columns = append(columns, sidecar)
+	}
+	if len(columns) < NumberOfColumns/2 {
+		return nil, fmt.Errorf("not enough columns to reconstruct blobs: got %d, need %d", len(columns), NumberOfColumns/2)
+	}
+
+	// the cell indices must be in ascending order
+	slices.SortFunc(columns, func(a, b *DataColumnSidecar) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	blobCount := len(columns[0].Column)
+	for _, column := range columns {
+		if len(column.Column) != blobCount {
+			return nil, fmt.Errorf("column %d has %d cells, expected %d", column.Index, len(column.Column), blobCount)
+		}
+	}
+	if blobCount == 0 {
+		return nil, nil
+	}
+
+	cellIndices := make([]uint64, len(columns))
+	for i, column := range columns {
+		cellIndices[i] = column.Index
+	}
+
+	// the cells are grouped by blob, each group holding the cells at cellIndices
+	cells := make([]kzg4844.Cell, 0, blobCount*len(columns))
+	for blob := 0; blob < blobCount; blob++ {
+		for _, column := range columns {
+			cells = append(cells, column.Column[blob])
+		}
+	}
+	return kzg4844.RecoverBlobs(cells, cellIndices)
+}
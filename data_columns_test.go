@@ -0,0 +1,225 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// newTestDataColumnSidecars builds all columns of the extended blob matrix of the blobs
+func newTestDataColumnSidecars(t *testing.T, blobs []kzg4844.Blob) []DataColumnSidecar {
+	t.Helper()
+	cells, err := kzg4844.ComputeCells(blobs)
+	if err != nil {
+		t.Fatalf("failed to compute cells: %v", err)
+	}
+
+	commitments := make([]kzg4844.Commitment, len(blobs))
+	proofs := make([][]kzg4844.Proof, len(blobs))
+	for i := range blobs {
+		if commitments[i], err = kzg4844.BlobToCommitment(&blobs[i]); err != nil {
+			t.Fatalf("failed to compute commitment: %v", err)
+		}
+		if proofs[i], err = kzg4844.ComputeCellProofs(&blobs[i]); err != nil {
+			t.Fatalf("failed to compute cell proofs: %v", err)
+		}
+	}
+
+	body := testMerkleTree{blobKZGCommitmentsGindex: kzgCommitmentsRoot(commitments)}
+	header := SignedBeaconBlockHeader{
+		Message: BeaconBlockHeader{Slot: 12345, ProposerIndex: 100, BodyRoot: body.root()},
+	}
+	inclusionProof := body.branch(blobKZGCommitmentsGindex)
+
+	sidecars := make([]DataColumnSidecar, NumberOfColumns)
+	for col := range sidecars {
+		sidecar := DataColumnSidecar{
+			Index:                        uint64(col),
+			KZGCommitments:               commitments,
+			SignedBlockHeader:            header,
+			KZGCommitmentsInclusionProof: inclusionProof,
+		}
+		for blob := range blobs {
+			sidecar.Column = append(sidecar.Column, cells[blob*NumberOfColumns+col])
+			sidecar.KZGProofs = append(sidecar.KZGProofs, proofs[blob][col])
+		}
+		sidecars[col] = sidecar
+	}
+	return sidecars
+}
+
+func TestGetDataColumnSidecars_Success(t *testing.T) {
+	sidecars := newTestDataColumnSidecars(t, loadTestBlobs(t)[:2])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/debug/beacon/data_column_sidecars/12345" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		indices := r.URL.Query()["indices"]
+		if len(indices) != 2 || indices[0] != "3" || indices[1] != "70" {
+			t.Errorf("unexpected indices: %v", indices)
+		}
+
+		data, err := json.Marshal(DataColumnSidecarsResponse{
+			Version:   ConsensusVersionFulu,
+			Finalized: true,
+			Data:      []DataColumnSidecar{sidecars[3], sidecars[70]},
+		})
+		if err != nil {
+			t.Fatalf("failed to encode sidecars: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithBlobVerification())
+	resp, err := client.GetDataColumnSidecars(context.Background(), "12345", 3, 70)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Version != ConsensusVersionFulu || !resp.Finalized {
+		t.Errorf("unexpected metadata: version=%s finalized=%v", resp.Version, resp.Finalized)
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 sidecars, got %d", len(resp.Data))
+	}
+	got := resp.Data[1]
+	if got.Index != 70 {
+		t.Errorf("expected index 70, got %d", got.Index)
+	}
+	if len(got.Column) != 2 || got.Column[1] != sidecars[70].Column[1] {
+		t.Error("column cells do not match")
+	}
+	if got.KZGCommitments[0] != sidecars[70].KZGCommitments[0] || got.KZGProofs[1] != sidecars[70].KZGProofs[1] {
+		t.Error("commitments or proofs do not match")
+	}
+	if got.SignedBlockHeader.Message.Slot != 12345 {
+		t.Errorf("expected header slot 12345, got %d", got.SignedBlockHeader.Message.Slot)
+	}
+	if len(got.KZGCommitmentsInclusionProof) != 4 {
+		t.Errorf("expected 4 inclusion proof nodes, got %d", len(got.KZGCommitmentsInclusionProof))
+	}
+}
+
+func TestGetDataColumnSidecars_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 404, "message": "Block not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.GetDataColumnSidecars(context.Background(), "head")
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Code != 404 {
+		t.Errorf("expected code 404, got %d", apiErr.Code)
+	}
+}
+
+func TestVerifyDataColumnSidecars(t *testing.T) {
+	sidecars := newTestDataColumnSidecars(t, loadTestBlobs(t)[:2])
+	if err := VerifyDataColumnSidecars(sidecars[:4]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		mutate     func(sidecar *DataColumnSidecar)
+		wantReason string
+	}{
+		{
+			name:       "proofs of another column",
+			mutate:     func(sidecar *DataColumnSidecar) { sidecar.KZGProofs = sidecars[1].KZGProofs },
+			wantReason: "invalid cell proofs",
+		},
+		{
+			name: "empty column",
+			mutate: func(sidecar *DataColumnSidecar) {
+				sidecar.Column, sidecar.KZGCommitments, sidecar.KZGProofs = nil, nil, nil
+			},
+			wantReason: "empty column",
+		},
+		{
+			name: "tampered inclusion proof",
+			mutate: func(sidecar *DataColumnSidecar) {
+				sidecar.KZGCommitmentsInclusionProof = slices.Clone(sidecar.KZGCommitmentsInclusionProof)
+				sidecar.KZGCommitmentsInclusionProof[3][0] ^= 1
+			},
+			wantReason: "invalid commitments inclusion proof",
+		},
+		{
+			name:       "header of another block",
+			mutate:     func(sidecar *DataColumnSidecar) { sidecar.SignedBlockHeader.Message.Slot++ },
+			wantReason: "block header differs from the other sidecars",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forged := append([]DataColumnSidecar(nil), sidecars[:4]...)
+			tt.mutate(&forged[2])
+
+			err := VerifyDataColumnSidecars(forged)
+			var verr *BlobVerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected *BlobVerificationError, got %T: %v", err, err)
+			}
+			if verr.Index != 2 {
+				t.Errorf("expected column index 2, got %d", verr.Index)
+			}
+			if verr.Reason != tt.wantReason {
+				t.Errorf("expected reason %q, got %q", tt.wantReason, verr.Reason)
+			}
+		})
+	}
+}
+
+func TestReconstructBlobs(t *testing.T) {
+	blobs := loadTestBlobs(t)[:2]
+	sidecars := newTestDataColumnSidecars(t, blobs)
+
+	// keep every other column in reverse order, which is exactly half of them
+	var half []DataColumnSidecar
+	for i := NumberOfColumns - 1; i >= 0; i -= 2 {
+		half = append(half, sidecars[i])
+	}
+
+	recovered, err := ReconstructBlobs(half)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recovered) != len(blobs) {
+		t.Fatalf("expected %d blobs, got %d", len(blobs), len(recovered))
+	}
+	for i := range blobs {
+		if recovered[i] != blobs[i] {
+			t.Errorf("blob %d does not match", i)
+		}
+	}
+}
+
+func TestReconstructBlobs_NotEnoughColumns(t *testing.T) {
+	sidecars := newTestDataColumnSidecars(t, loadTestBlobs(t)[:1])
+
+	// duplicates do not count towards the required columns
+	columns := append(sidecars[:NumberOfColumns/2-1:NumberOfColumns/2-1], sidecars[0])
+	if _, err := ReconstructBlobs(columns); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
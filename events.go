@@ -0,0 +1,393 @@
+package beaconclient
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+)
+
+// EventTopic represents a topic of the beacon node event stream
+type EventTopic string
+
+const (
+	EventTopicHead                        EventTopic = "head"
+	EventTopicBlock                       EventTopic = "block"
+	EventTopicBlockGossip                 EventTopic = "block_gossip"
+	EventTopicAttestation                 EventTopic = "attestation"
+	EventTopicSingleAttestation           EventTopic = "single_attestation"
+	EventTopicVoluntaryExit               EventTopic = "voluntary_exit"
+	EventTopicBLSToExecutionChange        EventTopic = "bls_to_execution_change"
+	EventTopicProposerSlashing            EventTopic = "proposer_slashing"
+	EventTopicAttesterSlashing            EventTopic = "attester_slashing"
+	EventTopicFinalizedCheckpoint         EventTopic = "finalized_checkpoint"
+	EventTopicChainReorg                  EventTopic = "chain_reorg"
+	EventTopicContributionAndProof        EventTopic = "contribution_and_proof"
+	EventTopicLightClientFinalityUpdate   EventTopic = "light_client_finality_update"
+	EventTopicLightClientOptimisticUpdate EventTopic = "light_client_optimistic_update"
+	EventTopicPayloadAttributes           EventTopic = "payload_attributes"
+	EventTopicBlobSidecar                 EventTopic = "blob_sidecar"
+	EventTopicDataColumnSidecar           EventTopic = "data_column_sidecar"
+)
+
+const (
+	// eventReconnectDelay is the initial delay before reconnecting a broken event stream
+	eventReconnectDelay = time.Second
+	// eventMaxReconnectDelay caps the delay between reconnection attempts
+	eventMaxReconnectDelay = 30 * time.Second
+)
+
+// Event is an event received from the beacon node event stream
+type Event struct {
+	// Topic is the topic of the event
+	Topic EventTopic
+	// Data is the decoded event data, see the topic's event type, e.g. *HeadEvent for EventTopicHead
+	// Topics without a typed representation are delivered as json.RawMessage
+	Data any
+	// Raw is the raw JSON data of the event
+	Raw json.RawMessage
+	// Err is set if the event data could not be decoded
+	Err error
+}
+
+// HeadEvent is the data of the head topic
+type HeadEvent struct {
+	Slot                      uint64      `json:"slot,string"`
+	Block                     common.Hash `json:"block"`
+	State                     common.Hash `json:"state"`
+	EpochTransition           bool        `json:"epoch_transition"`
+	PreviousDutyDependentRoot common.Hash `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  common.Hash `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool        `json:"execution_optimistic"`
+}
+
+// BlockEvent is the data of the block topic
+type BlockEvent struct {
+	Slot                uint64      `json:"slot,string"`
+	Block               common.Hash `json:"block"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+}
+
+// BlockGossipEvent is the data of the block_gossip topic
+type BlockGossipEvent struct {
+	Slot  uint64      `json:"slot,string"`
+	Block common.Hash `json:"block"`
+}
+
+// FinalizedCheckpointEvent is the data of the finalized_checkpoint topic
+type FinalizedCheckpointEvent struct {
+	Block               common.Hash `json:"block"`
+	State               common.Hash `json:"state"`
+	Epoch               uint64      `json:"epoch,string"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+}
+
+// ChainReorgEvent is the data of the chain_reorg topic
+type ChainReorgEvent struct {
+	Slot                uint64      `json:"slot,string"`
+	Depth               uint64      `json:"depth,string"`
+	OldHeadBlock        common.Hash `json:"old_head_block"`
+	NewHeadBlock        common.Hash `json:"new_head_block"`
+	OldHeadState        common.Hash `json:"old_head_state"`
+	NewHeadState        common.Hash `json:"new_head_state"`
+	Epoch               uint64      `json:"epoch,string"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+}
+
+// BlobSidecarEvent is the data of the blob_sidecar topic
+type BlobSidecarEvent struct {
+	BlockRoot     common.Hash        `json:"block_root"`
+	Index         uint64             `json:"index,string"`
+	Slot          uint64             `json:"slot,string"`
+	KZGCommitment kzg4844.Commitment `json:"kzg_commitment"`
+	VersionedHash common.Hash        `json:"versioned_hash"`
+}
+
+// DataColumnSidecarEvent is the data of the data_column_sidecar topic
+type DataColumnSidecarEvent struct {
+	BlockRoot      common.Hash          `json:"block_root"`
+	Index          uint64               `json:"index,string"`
+	Slot           uint64               `json:"slot,string"`
+	KZGCommitments []kzg4844.Commitment `json:"kzg_commitments"`
+}
+
+// PayloadAttributes contains the payload attributes of the fork of a PayloadAttributesEvent
+type PayloadAttributes struct {
+	Timestamp             uint64         `json:"timestamp,string"`
+	PrevRandao            common.Hash    `json:"prev_randao"`
+	SuggestedFeeRecipient common.Address `json:"suggested_fee_recipient"`
+	// Withdrawals is present from Capella
+	Withdrawals zrntcommon.Withdrawals `json:"withdrawals,omitempty"`
+	// ParentBeaconBlockRoot is present from Deneb
+	ParentBeaconBlockRoot *common.Hash `json:"parent_beacon_block_root,omitempty"`
+}
+
+// PayloadAttributesData contains the data of a PayloadAttributesEvent
+type PayloadAttributesData struct {
+	ProposerIndex     uint64            `json:"proposer_index,string"`
+	ProposalSlot      uint64            `json:"proposal_slot,string"`
+	ParentBlockNumber uint64            `json:"parent_block_number,string"`
+	ParentBlockRoot   common.Hash       `json:"parent_block_root"`
+	ParentBlockHash   common.Hash       `json:"parent_block_hash"`
+	PayloadAttributes PayloadAttributes `json:"payload_attributes"`
+}
+
+// PayloadAttributesEvent is the data of the payload_attributes topic
+type PayloadAttributesEvent struct {
+	Version ConsensusVersion      `json:"version"`
+	Data    PayloadAttributesData `json:"data"`
+}
+
+// decodeEvent decodes the data of an event according to its topic
+func decodeEvent(topic EventTopic, data []byte) (any, error) {
+	var v any
+	switch topic {
+	case EventTopicHead:
+		v = new(HeadEvent)
+	case EventTopicBlock:
+		v = new(BlockEvent)
+	case EventTopicBlockGossip:
+		v = new(BlockGossipEvent)
+	case EventTopicFinalizedCheckpoint:
+		v = new(FinalizedCheckpointEvent)
+	case EventTopicChainReorg:
+		v = new(ChainReorgEvent)
+	case EventTopicBlobSidecar:
+		v = new(BlobSidecarEvent)
+	case EventTopicDataColumnSidecar:
+		v = new(DataColumnSidecarEvent)
+	case EventTopicPayloadAttributes:
+		v = new(PayloadAttributesEvent)
+	case EventTopicVoluntaryExit:
+		v = new(phase0.SignedVoluntaryExit)
+	case EventTopicBLSToExecutionChange:
+		v = new(zrntcommon.SignedBLSToExecutionChange)
+	case EventTopicProposerSlashing:
+		v = new(phase0.ProposerSlashing)
+	case EventTopicAttesterSlashing:
+		// the JSON shape is identical for all forks, only the SSZ list limits differ
+		v = new(electra.AttesterSlashing)
+	case EventTopicSingleAttestation:
+		v = new(electra.SingleAttestation)
+	case EventTopicContributionAndProof:
+		v = new(altair.SignedContributionAndProof)
+	case EventTopicLightClientFinalityUpdate:
+		v = new(LightClientFinalityUpdateResponse)
+	case EventTopicLightClientOptimisticUpdate:
+		v = new(LightClientOptimisticUpdateResponse)
+	case EventTopicAttestation:
+		// return a nil interface on error, not a nil *VersionedAttestation
+		attestation, err := decodeAttestationEvent(data)
+		if err != nil {
+			return nil, err
+		}
+		return attestation, nil
+	default:
+		return json.RawMessage(data), nil
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", topic, err)
+	}
+	return v, nil
+}
+
+// decodeAttestationEvent decodes an attestation of any fork
+// The event carries no version, Electra attestations are recognized by their committee_bits
+func decodeAttestationEvent(data []byte) (*VersionedAttestation, error) {
+	var probe struct {
+		CommitteeBits json.RawMessage `json:"committee_bits"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to decode attestation event: %w", err)
+	}
+
+	if probe.CommitteeBits != nil {
+		var attestation electra.Attestation
+		if err := json.Unmarshal(data, &attestation); err != nil {
+			return nil, fmt.Errorf("failed to decode attestation event: %w", err)
+		}
+		return &VersionedAttestation{Version: ConsensusVersionElectra, Electra: &attestation}, nil
+	}
+
+	var attestation phase0.Attestation
+	if err := json.Unmarshal(data, &attestation); err != nil {
+		return nil, fmt.Errorf("failed to decode attestation event: %w", err)
+	}
+	return &VersionedAttestation{Version: ConsensusVersionPhase0, Phase0: &attestation}, nil
+}
+
+// SubscribeEvents subscribes to the beacon node event stream for the given topics
+// Endpoint: GET /eth/v1/events
+//
+// The initial connection is established before returning, so errors such as an unknown topic
+// are returned directly. Afterwards the stream is reconnected automatically with backoff when
+// it breaks. The returned channel is closed once ctx is cancelled.
+//
+// Events are delivered in order; a slow consumer blocks reading from the stream.
+func (c *Client) SubscribeEvents(ctx context.Context, topics ...EventTopic) (<-chan Event, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("at least one topic is required")
+	}
+
+	query := url.Values{}
+	for _, topic := range topics {
+		query.Add("topics", string(topic))
+	}
+
+	body, err := c.openEventStream(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event, 16)
+	go func() {
+		defer close(events)
+
+		reader := &eventReader{events: events}
+		// delay is the backoff, it only starts over after a connection that delivered events,
+		// so a node closing the stream right away is not reconnected to at the initial delay forever
+		var delay time.Duration
+		for {
+			reader.received = false
+			reader.read(ctx, body)
+			//nolint:errcheck
+			body.Close()
+
+			// the reconnection delay requested by the server applies to all later reconnections
+			reconnectDelay := cmp.Or(reader.retry, eventReconnectDelay)
+			maxDelay := max(reconnectDelay, eventMaxReconnectDelay)
+			if reader.received || delay == 0 {
+				delay = reconnectDelay
+			} else {
+				delay = min(delay*2, maxDelay)
+			}
+			for {
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+
+				body, err = c.openEventStream(ctx, query)
+				if err == nil {
+					break
+				}
+				delay = min(delay*2, maxDelay)
+			}
+		}
+	}()
+	return events, nil
+}
+
+// openEventStream connects to the event stream and returns the response body
+func (c *Client) openEventStream(ctx context.Context, query url.Values) (io.ReadCloser, error) {
+	node := c
+	if c.pool != nil {
+		node = c.pool.best()
+	}
+
+	req, err := node.newRequest(ctx, http.MethodGet, "/eth/v1/events", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// the stream is long-lived, so the request timeout must not apply
+	httpClient := *node.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		//nolint:errcheck
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		var apiErr APIError
+		if err := json.Unmarshal(body, &apiErr); err != nil {
+			return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, &apiErr
+	}
+	return resp.Body, nil
+}
+
+// eventReader parses server-sent events and delivers them
+// Its fields carry the state of the event stream over reconnections.
+type eventReader struct {
+	events chan<- Event
+	// retry is the reconnection delay last requested by the server
+	retry time.Duration
+	// received reports whether an event was delivered
+	received bool
+}
+
+// read parses the server-sent events of body and delivers them until the stream ends
+func (r *eventReader) read(ctx context.Context, body io.Reader) {
+	var (
+		topic string
+		data  bytes.Buffer
+	)
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// a blank line dispatches the event
+			if data.Len() > 0 {
+				raw := json.RawMessage(bytes.Clone(bytes.TrimSuffix(data.Bytes(), []byte("\n"))))
+				event := Event{Topic: EventTopic(topic), Raw: raw}
+				event.Data, event.Err = decodeEvent(event.Topic, raw)
+				select {
+				case r.events <- event:
+					r.received = true
+				case <-ctx.Done():
+					return
+				}
+			}
+			topic = ""
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			topic = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 32); err == nil {
+				r.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
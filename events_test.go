@@ -0,0 +1,247 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSubscribeEvents_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/events" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("unexpected Accept header: %s", r.Header.Get("Accept"))
+		}
+		topics := r.URL.Query()["topics"]
+		if len(topics) != 3 {
+			t.Errorf("unexpected topics: %v", topics)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(": keep-alive\n\n" +
+			"event: head\n" +
+			`data: {"slot":"10", "block":"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", "state":"0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", "epoch_transition":false, "previous_duty_dependent_root":"0x5e0043f107cb57913498fbf2f99ff55e730bf1e151f02f221e977c91a90a0e91", "current_duty_dependent_root":"0x5e0043f107cb57913498fbf2f99ff55e730bf1e151f02f221e977c91a90a0e91", "execution_optimistic": false}` + "\n\n" +
+			"event: attestation\n" +
+			`data: {"aggregation_bits":"0x01", "data":{"slot":"1", "index":"1", "beacon_block_root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2", "source":{"epoch":"1", "root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"}, "target":{"epoch":"1", "root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"}}, "signature":"0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505", "committee_bits":"0x0100000000000000"}` + "\n\n" +
+			"event: finalized_checkpoint\n" +
+			"data: {\"block\":\"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf\",\n" +
+			"data: \"state\":\"0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9\", \"epoch\":\"2\", \"execution_optimistic\": false}\n\n"))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := NewClient(server.URL)
+	events, err := client.SubscribeEvents(ctx, EventTopicHead, EventTopicAttestation, EventTopicFinalizedCheckpoint)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	event := <-events
+	if event.Err != nil {
+		t.Fatalf("unexpected event error: %v", event.Err)
+	}
+	head, ok := event.Data.(*HeadEvent)
+	if !ok {
+		t.Fatalf("expected *HeadEvent, got %T", event.Data)
+	}
+	if head.Slot != 10 {
+		t.Errorf("expected slot 10, got %d", head.Slot)
+	}
+	if head.Block != common.HexToHash("0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf") {
+		t.Errorf("unexpected block: %s", head.Block.Hex())
+	}
+
+	event = <-events
+	attestation, ok := event.Data.(*VersionedAttestation)
+	if !ok {
+		t.Fatalf("expected *VersionedAttestation, got %T (%v)", event.Data, event.Err)
+	}
+	if attestation.Electra == nil {
+		t.Error("expected electra attestation")
+	}
+
+	event = <-events
+	finalized, ok := event.Data.(*FinalizedCheckpointEvent)
+	if !ok {
+		t.Fatalf("expected *FinalizedCheckpointEvent, got %T (%v)", event.Data, event.Err)
+	}
+	if finalized.Epoch != 2 {
+		t.Errorf("expected epoch 2, got %d", finalized.Epoch)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestSubscribeEvents_Reconnect(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := connections.Add(1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("retry: 10\n\nevent: block\ndata: {\"slot\":\"" + string(rune('0'+n)) + "\", \"block\":\"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf\"}\n\n"))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := NewClient(server.URL, WithTimeout(time.Millisecond*500))
+	events, err := client.SubscribeEvents(ctx, EventTopicBlock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for want := uint64(1); want <= 3; want++ {
+		event := <-events
+		block, ok := event.Data.(*BlockEvent)
+		if !ok {
+			t.Fatalf("expected *BlockEvent, got %T (%v)", event.Data, event.Err)
+		}
+		if block.Slot != want {
+			t.Errorf("expected slot %d, got %d", want, block.Slot)
+		}
+	}
+}
+
+func TestSubscribeEvents_ReconnectDelay(t *testing.T) {
+	tests := []struct {
+		name           string
+		event          string
+		minConnections int32
+		maxConnections int32
+	}{
+		// the delay stays at the server's retry value while the streams deliver events
+		{name: "events", event: "event: head\ndata: {}\n\n", minConnections: 10, maxConnections: math.MaxInt32},
+		// streams closed right away are reconnected with a growing backoff
+		{name: "empty streams", minConnections: 2, maxConnections: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var connections atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := connections.Add(1)
+				w.Header().Set("Content-Type", "text/event-stream")
+				w.WriteHeader(http.StatusOK)
+				// the retry field is only sent once
+				if n == 1 {
+					_, _ = w.Write([]byte("retry: 10\n\n"))
+				}
+				_, _ = w.Write([]byte(tt.event))
+			}))
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			events, err := NewClient(server.URL).SubscribeEvents(ctx, EventTopicHead)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for range events {
+			}
+
+			if n := connections.Load(); n < tt.minConnections || n > tt.maxConnections {
+				t.Errorf("expected %d to %d connections, got %d", tt.minConnections, tt.maxConnections, n)
+			}
+		})
+	}
+}
+
+func TestSubscribeEvents_InvalidTopic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code": 400, "message": "Invalid topic: unknown"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.SubscribeEvents(context.Background(), "unknown")
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Code != 400 {
+		t.Errorf("expected code 400, got %d", apiErr.Code)
+	}
+}
+
+func TestReadEvents_UnknownTopic(t *testing.T) {
+	events := make(chan Event, 1)
+	stream := "event: new_topic\ndata: {\"foo\":\"bar\"}\n\n"
+	reader := &eventReader{events: events}
+	reader.read(context.Background(), strings.NewReader(stream))
+	if !reader.received {
+		t.Fatal("expected an event")
+	}
+
+	event := <-events
+	raw, ok := event.Data.(json.RawMessage)
+	if !ok {
+		t.Fatalf("expected json.RawMessage, got %T", event.Data)
+	}
+	if string(raw) != `{"foo":"bar"}` {
+		t.Errorf("unexpected raw data: %s", raw)
+	}
+}
+
+func TestReadEvents_DecodeError(t *testing.T) {
+	events := make(chan Event, 1)
+	stream := "event: head\ndata: {\"slot\": 1}\n\n"
+	(&eventReader{events: events}).read(context.Background(), strings.NewReader(stream))
+
+	event := <-events
+	if event.Err == nil {
+		t.Fatal("expected decode error")
+	}
+	if string(event.Raw) != `{"slot": 1}` {
+		t.Errorf("unexpected raw data: %s", event.Raw)
+	}
+}
+
+func TestReadEvents_AttestationDecodeError(t *testing.T) {
+	events := make(chan Event, 1)
+	stream := "event: attestation\ndata: {\"aggregation_bits\": 1}\n\n"
+	(&eventReader{events: events}).read(context.Background(), strings.NewReader(stream))
+
+	event := <-events
+	if event.Err == nil {
+		t.Fatal("expected decode error")
+	}
+	if event.Data != nil {
+		t.Errorf("expected no data for a malformed attestation, got %T", event.Data)
+	}
+}
+
+func TestReadEvents_LightClientOptimisticUpdate(t *testing.T) {
+	events := make(chan Event, 1)
+	stream := "event: light_client_optimistic_update\n" +
+		`data: {"version":"altair","data":{"attested_header":{"beacon":{"slot":"1","proposer_index":"1","parent_root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2","state_root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2","body_root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"}},"sync_aggregate":{"sync_committee_bits":"0x01","sync_committee_signature":"0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"},"signature_slot":"2"}}` + "\n\n"
+	(&eventReader{events: events}).read(context.Background(), strings.NewReader(stream))
+
+	event := <-events
+	update, ok := event.Data.(*LightClientOptimisticUpdateResponse)
+	if !ok {
+		t.Fatalf("expected *LightClientOptimisticUpdateResponse, got %T (%v)", event.Data, event.Err)
+	}
+	if update.Version != ConsensusVersionAltair {
+		t.Errorf("expected altair, got %s", update.Version)
+	}
+	if update.Data.SignatureSlot != 2 || update.Data.AttestedHeader.Beacon.Slot != 1 {
+		t.Errorf("unexpected update: %+v", update.Data)
+	}
+}
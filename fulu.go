@@ -0,0 +1,163 @@
+package beaconclient
+
+import (
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// Fulu (PeerDAS) does not modify the block containers, the fields are the Electra ones,
+// but a dedicated type keeps Fulu blocks apart from Electra blocks as the forks diverge.
+
+// FuluSignedBeaconBlock is the Fulu SignedBeaconBlock container
+type FuluSignedBeaconBlock struct {
+	Message   FuluBeaconBlock         `json:"message" yaml:"message"`
+	Signature zrntcommon.BLSSignature `json:"signature" yaml:"signature"`
+}
+
+func (b *FuluSignedBeaconBlock) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *FuluSignedBeaconBlock) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *FuluSignedBeaconBlock) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(spec.Wrap(&b.Message), &b.Signature)
+}
+
+func (b *FuluSignedBeaconBlock) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *FuluSignedBeaconBlock) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(spec.Wrap(&b.Message), b.Signature)
+}
+
+// SignedHeader returns the signed header of the block
+func (b *FuluSignedBeaconBlock) SignedHeader(spec *zrntcommon.Spec) *zrntcommon.SignedBeaconBlockHeader {
+	return &zrntcommon.SignedBeaconBlockHeader{
+		Message:   *b.Message.Header(spec),
+		Signature: b.Signature,
+	}
+}
+
+// FuluBeaconBlock is the Fulu BeaconBlock container
+type FuluBeaconBlock struct {
+	Slot          zrntcommon.Slot           `json:"slot" yaml:"slot"`
+	ProposerIndex zrntcommon.ValidatorIndex `json:"proposer_index" yaml:"proposer_index"`
+	ParentRoot    zrntcommon.Root           `json:"parent_root" yaml:"parent_root"`
+	StateRoot     zrntcommon.Root           `json:"state_root" yaml:"state_root"`
+	Body          FuluBeaconBlockBody       `json:"body" yaml:"body"`
+}
+
+func (b *FuluBeaconBlock) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *FuluBeaconBlock) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *FuluBeaconBlock) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(&b.Slot, &b.ProposerIndex, &b.ParentRoot, &b.StateRoot, spec.Wrap(&b.Body))
+}
+
+func (b *FuluBeaconBlock) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *FuluBeaconBlock) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, spec.Wrap(&b.Body))
+}
+
+// Header returns the header of the block
+func (b *FuluBeaconBlock) Header(spec *zrntcommon.Spec) *zrntcommon.BeaconBlockHeader {
+	return &zrntcommon.BeaconBlockHeader{
+		Slot:          b.Slot,
+		ProposerIndex: b.ProposerIndex,
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+		BodyRoot:      b.Body.HashTreeRoot(spec, tree.GetHashFn()),
+	}
+}
+
+// FuluBeaconBlockBody is the Fulu BeaconBlockBody container
+type FuluBeaconBlockBody struct {
+	RandaoReveal zrntcommon.BLSSignature `json:"randao_reveal" yaml:"randao_reveal"`
+	Eth1Data     zrntcommon.Eth1Data     `json:"eth1_data" yaml:"eth1_data"`
+	Graffiti     zrntcommon.Root         `json:"graffiti" yaml:"graffiti"`
+
+	ProposerSlashings phase0.ProposerSlashings  `json:"proposer_slashings" yaml:"proposer_slashings"`
+	AttesterSlashings electra.AttesterSlashings `json:"attester_slashings" yaml:"attester_slashings"`
+	Attestations      electra.Attestations      `json:"attestations" yaml:"attestations"`
+	Deposits          phase0.Deposits           `json:"deposits" yaml:"deposits"`
+	VoluntaryExits    phase0.VoluntaryExits     `json:"voluntary_exits" yaml:"voluntary_exits"`
+	SyncAggregate     altair.SyncAggregate      `json:"sync_aggregate" yaml:"sync_aggregate"`
+
+	ExecutionPayload      deneb.ExecutionPayload                 `json:"execution_payload" yaml:"execution_payload"`
+	BLSToExecutionChanges zrntcommon.SignedBLSToExecutionChanges `json:"bls_to_execution_changes" yaml:"bls_to_execution_changes"`
+	BlobKZGCommitments    deneb.KZGCommitments                   `json:"blob_kzg_commitments" yaml:"blob_kzg_commitments"`
+	ExecutionRequests     electra.ExecutionRequests              `json:"execution_requests" yaml:"execution_requests"`
+}
+
+func (b *FuluBeaconBlockBody) Deserialize(spec *zrntcommon.Spec, dr *codec.DecodingReader) error {
+	return dr.Container(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), spec.Wrap(&b.ExecutionPayload),
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *FuluBeaconBlockBody) Serialize(spec *zrntcommon.Spec, w *codec.EncodingWriter) error {
+	return w.Container(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), spec.Wrap(&b.ExecutionPayload),
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *FuluBeaconBlockBody) ByteLength(spec *zrntcommon.Spec) uint64 {
+	return codec.ContainerLength(
+		&b.RandaoReveal, &b.Eth1Data,
+		&b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), spec.Wrap(&b.ExecutionPayload),
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
+
+func (b *FuluBeaconBlockBody) FixedLength(*zrntcommon.Spec) uint64 {
+	return 0
+}
+
+func (b *FuluBeaconBlockBody) HashTreeRoot(spec *zrntcommon.Spec, hFn tree.HashFn) zrntcommon.Root {
+	return hFn.HashTreeRoot(
+		b.RandaoReveal, &b.Eth1Data,
+		b.Graffiti, spec.Wrap(&b.ProposerSlashings),
+		spec.Wrap(&b.AttesterSlashings), spec.Wrap(&b.Attestations),
+		spec.Wrap(&b.Deposits), spec.Wrap(&b.VoluntaryExits),
+		spec.Wrap(&b.SyncAggregate), spec.Wrap(&b.ExecutionPayload),
+		spec.Wrap(&b.BLSToExecutionChanges),
+		spec.Wrap(&b.BlobKZGCommitments),
+		spec.Wrap(&b.ExecutionRequests),
+	)
+}
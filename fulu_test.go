@@ -0,0 +1,144 @@
+package beaconclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// loadFuluBlock decodes testdata/fulu.block.json into a FuluSignedBeaconBlock
+func loadFuluBlock(t *testing.T) (*FuluSignedBeaconBlock, []byte) {
+	t.Helper()
+	block := loadSignedBlock(t, "testdata/fulu.block.json")
+	data, err := json.Marshal(block.Data)
+	if err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	var signed FuluSignedBeaconBlock
+	if err := json.Unmarshal(data, &signed); err != nil {
+		t.Fatalf("failed to decode fulu block: %v", err)
+	}
+	return &signed, data
+}
+
+func TestFuluBeaconBlock_JSON(t *testing.T) {
+	signed, data := loadFuluBlock(t)
+
+	if signed.Message.Slot != 13410020 {
+		t.Errorf("expected slot 13410020, got %d", signed.Message.Slot)
+	}
+	if signed.Message.ProposerIndex != 1797581 {
+		t.Errorf("expected proposer 1797581, got %d", signed.Message.ProposerIndex)
+	}
+	if len(signed.Message.Body.Attestations) == 0 {
+		t.Error("expected attestations")
+	}
+	if signed.Message.Body.ExecutionPayload.BlockNumber == 0 {
+		t.Error("expected execution payload")
+	}
+
+	// encoding the block again must yield the original document
+	encoded, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatalf("failed to encode fulu block: %v", err)
+	}
+	var want, got any
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Error("JSON round trip does not match the original block")
+	}
+}
+
+func TestFuluBeaconBlock_SSZ(t *testing.T) {
+	spec := configs.Mainnet
+	signed, data := loadFuluBlock(t)
+
+	var encoded bytes.Buffer
+	if err := spec.Wrap(signed).Serialize(codec.NewEncodingWriter(&encoded)); err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	if uint64(encoded.Len()) != signed.ByteLength(spec) {
+		t.Errorf("expected %d bytes, got %d", signed.ByteLength(spec), encoded.Len())
+	}
+
+	var decoded FuluSignedBeaconBlock
+	if err := spec.Wrap(&decoded).Deserialize(codec.NewDecodingReader(bytes.NewReader(encoded.Bytes()), uint64(encoded.Len()))); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	root := signed.HashTreeRoot(spec, tree.GetHashFn())
+	if decoded.HashTreeRoot(spec, tree.GetHashFn()) != root {
+		t.Error("decoded block root does not match")
+	}
+
+	// Fulu did not modify the block containers, so the roots match the Electra ones
+	var asElectra electra.SignedBeaconBlock
+	if err := json.Unmarshal(data, &asElectra); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	if asElectra.HashTreeRoot(spec, tree.GetHashFn()) != root {
+		t.Error("fulu block root differs from the electra container root")
+	}
+
+	header := signed.SignedHeader(spec)
+	if header.Message.BodyRoot != signed.Message.Body.HashTreeRoot(spec, tree.GetHashFn()) {
+		t.Error("header body root does not match the body")
+	}
+	if header.Message.HashTreeRoot(tree.GetHashFn()) != signed.Message.HashTreeRoot(spec, tree.GetHashFn()) {
+		t.Error("header root does not match the block root")
+	}
+}
+
+func TestGetBlockSSZ_Fulu(t *testing.T) {
+	spec := configs.Mainnet
+	signed, _ := loadFuluBlock(t)
+	var encoded bytes.Buffer
+	if err := spec.Wrap(signed).Serialize(codec.NewEncodingWriter(&encoded)); err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Eth-Consensus-Version", "fulu")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(encoded.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetBlockSSZ(context.Background(), &Spec{Spec: *spec}, "head")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, ok := resp.Data.(*FuluSignedBeaconBlock)
+	if !ok {
+		t.Fatalf("expected *FuluSignedBeaconBlock, got %T", resp.Data)
+	}
+	if got.HashTreeRoot(spec, tree.GetHashFn()) != signed.HashTreeRoot(spec, tree.GetHashFn()) {
+		t.Error("decoded block root does not match")
+	}
+
+	versioned, err := resp.Block()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if versioned.Fulu == nil || versioned.Slot() != 13410020 {
+		t.Errorf("unexpected versioned block: %+v", versioned)
+	}
+	if _, ok := versioned.BlobKZGCommitments(); !ok {
+		t.Error("expected blob commitments for a fulu block")
+	}
+}
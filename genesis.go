@@ -3,7 +3,10 @@ package beaconclient
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
 )
 
 // genesisResponse represents the full response from /eth/v1/beacon/genesis
@@ -18,6 +21,18 @@ type GenesisData struct {
 	GenesisForkVersion    string `json:"genesis_fork_version"`
 }
 
+// validatorsRoot parses the genesis validators root
+func (g *GenesisData) validatorsRoot() (zrntcommon.Root, error) {
+	if g == nil {
+		return zrntcommon.Root{}, fmt.Errorf("genesis data is required")
+	}
+	var root zrntcommon.Root
+	if err := root.UnmarshalText([]byte(g.GenesisValidatorsRoot)); err != nil {
+		return zrntcommon.Root{}, fmt.Errorf("invalid genesis validators root: %w", err)
+	}
+	return root, nil
+}
+
 // GetGenesis retrieves details of the chain's genesis
 // Endpoint: GET /eth/v1/beacon/genesis
 func (c *Client) GetGenesis(ctx context.Context) (*GenesisData, error) {
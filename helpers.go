@@ -1,5 +1,15 @@
 package beaconclient
 
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
 // GENESIS_SLOT is the first slot of the beacon chain
 const GENESIS_SLOT uint64 = 0
 
@@ -29,10 +39,333 @@ func ComputeTimestampAtSlot(genesisTime, slot, secondsPerSlot uint64) uint64 {
 //   - timestamp: the Unix timestamp
 //   - secondsPerSlot: seconds per slot from SpecData (typically 12)
 //
-// Returns the slot number at the given timestamp
+// Returns the slot number at the given timestamp, GENESIS_SLOT if secondsPerSlot is zero
 func ComputeSlotAtTimestamp(genesisTime, timestamp, secondsPerSlot uint64) uint64 {
-	if timestamp < genesisTime {
+	if timestamp < genesisTime || secondsPerSlot == 0 {
 		return GENESIS_SLOT
 	}
 	return (timestamp - genesisTime) / secondsPerSlot
 }
+
+// ComputeEpochAtSlot computes the epoch of a given slot
+// This is equivalent to the Python spec function:
+//
+//	def compute_epoch_at_slot(slot: Slot) -> Epoch:
+//	    return Epoch(slot // SLOTS_PER_EPOCH)
+//
+// Returns 0 if the spec is nil or SLOTS_PER_EPOCH is not set
+func ComputeEpochAtSlot(spec *Spec, slot uint64) uint64 {
+	if spec == nil || spec.SLOTS_PER_EPOCH == 0 {
+		return 0
+	}
+	return slot / uint64(spec.SLOTS_PER_EPOCH)
+}
+
+// ComputeStartSlotAtEpoch computes the first slot of a given epoch
+// This is equivalent to the Python spec function:
+//
+//	def compute_start_slot_at_epoch(epoch: Epoch) -> Slot:
+//	    return Slot(epoch * SLOTS_PER_EPOCH)
+//
+// Returns 0 if the spec is nil or SLOTS_PER_EPOCH is not set
+func ComputeStartSlotAtEpoch(spec *Spec, epoch uint64) uint64 {
+	if spec == nil || spec.SLOTS_PER_EPOCH == 0 {
+		return 0
+	}
+	return epoch * uint64(spec.SLOTS_PER_EPOCH)
+}
+
+// ComputeEndSlotAtEpoch computes the last slot of a given epoch
+// Returns 0 if the spec is nil or SLOTS_PER_EPOCH is not set
+func ComputeEndSlotAtEpoch(spec *Spec, epoch uint64) uint64 {
+	if spec == nil || spec.SLOTS_PER_EPOCH == 0 {
+		return 0
+	}
+	return ComputeStartSlotAtEpoch(spec, epoch+1) - 1
+}
+
+// ComputeSyncCommitteePeriod computes the sync committee period of a given epoch
+// This is equivalent to the Python spec function:
+//
+//	def compute_sync_committee_period(epoch: Epoch) -> uint64:
+//	    return epoch // EPOCHS_PER_SYNC_COMMITTEE_PERIOD
+//
+// Returns 0 if the spec is nil or EPOCHS_PER_SYNC_COMMITTEE_PERIOD is not set
+func ComputeSyncCommitteePeriod(spec *Spec, epoch uint64) uint64 {
+	if spec == nil || spec.EPOCHS_PER_SYNC_COMMITTEE_PERIOD == 0 {
+		return 0
+	}
+	return epoch / uint64(spec.EPOCHS_PER_SYNC_COMMITTEE_PERIOD)
+}
+
+// ComputeSyncCommitteePeriodAtSlot computes the sync committee period of a given slot
+// This is equivalent to the Python spec function:
+//
+//	def compute_sync_committee_period_at_slot(slot: Slot) -> uint64:
+//	    return compute_sync_committee_period(compute_epoch_at_slot(slot))
+func ComputeSyncCommitteePeriodAtSlot(spec *Spec, slot uint64) uint64 {
+	return ComputeSyncCommitteePeriod(spec, ComputeEpochAtSlot(spec, slot))
+}
+
+// ComputeStartEpochAtSyncCommitteePeriod computes the first epoch of a given sync committee period
+// Returns 0 if the spec is nil or EPOCHS_PER_SYNC_COMMITTEE_PERIOD is not set
+func ComputeStartEpochAtSyncCommitteePeriod(spec *Spec, period uint64) uint64 {
+	if spec == nil || spec.EPOCHS_PER_SYNC_COMMITTEE_PERIOD == 0 {
+		return 0
+	}
+	return period * uint64(spec.EPOCHS_PER_SYNC_COMMITTEE_PERIOD)
+}
+
+// ConsensusVersionAtEpoch returns the consensus version active at a given epoch
+// Returns an empty version if the spec is nil
+func ConsensusVersionAtEpoch(spec *Spec, epoch uint64) ConsensusVersion {
+	if spec == nil {
+		return ""
+	}
+	current, _ := scheduledForkAtEpoch(spec, epoch)
+	return current.version
+}
+
+// ConsensusVersionAtSlot returns the consensus version active at a given slot
+// Returns an empty version if the spec is nil
+func ConsensusVersionAtSlot(spec *Spec, slot uint64) ConsensusVersion {
+	return ConsensusVersionAtEpoch(spec, ComputeEpochAtSlot(spec, slot))
+}
+
+// ForkEpoch returns the activation epoch of a consensus version
+// Forks that are not scheduled have FAR_FUTURE_EPOCH (math.MaxUint64) as activation epoch
+func ForkEpoch(spec *Spec, version ConsensusVersion) (uint64, error) {
+	if spec == nil {
+		return 0, fmt.Errorf("spec is required to compute fork epochs")
+	}
+	for _, fork := range forkSchedule(spec) {
+		if fork.version == version {
+			return fork.epoch, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported consensus version: %s", version)
+}
+
+// IsForkBoundary reports whether a slot is the first slot of a fork
+// Returns false if the spec is nil
+func IsForkBoundary(spec *Spec, slot uint64) bool {
+	if spec == nil || spec.SLOTS_PER_EPOCH == 0 || slot%uint64(spec.SLOTS_PER_EPOCH) != 0 {
+		return false
+	}
+	epoch := ComputeEpochAtSlot(spec, slot)
+	for _, fork := range forkSchedule(spec)[1:] {
+		if fork.epoch == epoch {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduledFork is a fork of the spec together with its activation epoch
+type scheduledFork struct {
+	version     ConsensusVersion
+	epoch       uint64
+	forkVersion zrntcommon.Version
+}
+
+// forkSchedule returns the forks of the spec in activation order
+// Forks that are not scheduled have FAR_FUTURE_EPOCH as activation epoch
+func forkSchedule(spec *Spec) []scheduledFork {
+	schedule := []scheduledFork{
+		{ConsensusVersionPhase0, 0, spec.GENESIS_FORK_VERSION},
+		{ConsensusVersionAltair, uint64(spec.ALTAIR_FORK_EPOCH), spec.ALTAIR_FORK_VERSION},
+		{ConsensusVersionBellatrix, uint64(spec.BELLATRIX_FORK_EPOCH), spec.BELLATRIX_FORK_VERSION},
+		{ConsensusVersionCapella, uint64(spec.CAPELLA_FORK_EPOCH), spec.CAPELLA_FORK_VERSION},
+		{ConsensusVersionDeneb, uint64(spec.DENEB_FORK_EPOCH), spec.DENEB_FORK_VERSION},
+		{ConsensusVersionElectra, uint64(spec.ELECTRA_FORK_EPOCH), spec.ELECTRA_FORK_VERSION},
+		{ConsensusVersionFulu, uint64(spec.FULU_FORK_EPOCH), spec.FULU_FORK_VERSION},
+	}
+	// nodes omit the keys of forks they do not know, which leaves epoch and version at zero,
+	// so a later fork at genesis without its own fork version is not scheduled
+	for i := 1; i < len(schedule); i++ {
+		if schedule[i].epoch == 0 && schedule[i].forkVersion == (zrntcommon.Version{}) {
+			schedule[i].epoch = math.MaxUint64
+		}
+	}
+	return schedule
+}
+
+// scheduledForkEpoch returns the activation epoch of a fork known to forkSchedule
+func scheduledForkEpoch(spec *Spec, version ConsensusVersion) uint64 {
+	epoch, _ := ForkEpoch(spec, version)
+	return epoch
+}
+
+// scheduledForkAtEpoch returns the fork active at epoch and the fork before it
+func scheduledForkAtEpoch(spec *Spec, epoch uint64) (current, previous scheduledFork) {
+	schedule := forkSchedule(spec)
+	current, previous = schedule[0], schedule[0]
+	for _, fork := range schedule[1:] {
+		if epoch < fork.epoch {
+			break
+		}
+		previous, current = current, fork
+	}
+	return current, previous
+}
+
+// ForkAtEpoch returns the fork active at a given epoch, as it appears in the beacon state
+//
+// Parameters:
+//   - spec: the spec from GetSpec, providing the fork versions and epochs
+//   - epoch: the target epoch
+//
+// Returns the fork with its previous and current versions and activation epoch,
+// or an empty fork if the spec is nil
+func ForkAtEpoch(spec *Spec, epoch uint64) Fork {
+	if spec == nil {
+		return Fork{}
+	}
+	current, previous := scheduledForkAtEpoch(spec, epoch)
+	return Fork{
+		PreviousVersion: previous.forkVersion,
+		CurrentVersion:  current.forkVersion,
+		Epoch:           current.epoch,
+	}
+}
+
+// blobParametersAtEpoch returns the blob parameters active at a given epoch
+// This is equivalent to the Python spec function get_blob_parameters from Fulu
+func blobParametersAtEpoch(spec *Spec, epoch uint64) (activationEpoch, maxBlobsPerBlock uint64) {
+	found := false
+	for _, entry := range spec.BLOB_SCHEDULE {
+		if uint64(entry.Epoch) <= epoch && (!found || uint64(entry.Epoch) >= activationEpoch) {
+			activationEpoch, maxBlobsPerBlock = uint64(entry.Epoch), uint64(entry.MaxBlobsPerBlock)
+			found = true
+		}
+	}
+	if !found {
+		return scheduledForkEpoch(spec, ConsensusVersionElectra), uint64(spec.MAX_BLOBS_PER_BLOCK_ELECTRA)
+	}
+	return activationEpoch, maxBlobsPerBlock
+}
+
+// ComputeForkDigest computes the fork digest used in p2p topics and ENRs at a given epoch
+// This is equivalent to the Python spec function (as modified in Fulu):
+//
+//	def compute_fork_digest(genesis_validators_root: Root, epoch: Epoch) -> ForkDigest:
+//	    fork_version = compute_fork_version(epoch)
+//	    base_digest = compute_fork_data_root(fork_version, genesis_validators_root)
+//	    if epoch < FULU_FORK_EPOCH:
+//	        return ForkDigest(base_digest[:4])
+//	    blob_parameters = get_blob_parameters(epoch)
+//	    return ForkDigest(
+//	        bytes(xor(base_digest, hash(uint_to_bytes(uint64(blob_parameters.epoch))
+//	            + uint_to_bytes(uint64(blob_parameters.max_blobs_per_block)))))[:4]
+//	    )
+//
+// Parameters:
+//   - spec: the spec from GetSpec, providing the fork versions, epochs and BLOB_SCHEDULE
+//   - genesis: the genesis data from GetGenesis
+//   - epoch: the target epoch
+func ComputeForkDigest(spec *Spec, genesis *GenesisData, epoch uint64) (zrntcommon.ForkDigest, error) {
+	if spec == nil {
+		return zrntcommon.ForkDigest{}, fmt.Errorf("spec is required to compute fork digests")
+	}
+	genesisValidatorsRoot, err := genesis.validatorsRoot()
+	if err != nil {
+		return zrntcommon.ForkDigest{}, err
+	}
+
+	current, _ := scheduledForkAtEpoch(spec, epoch)
+	baseDigest := zrntcommon.ComputeForkDataRoot(current.forkVersion, genesisValidatorsRoot)
+
+	var digest zrntcommon.ForkDigest
+	if epoch < scheduledForkEpoch(spec, ConsensusVersionFulu) {
+		copy(digest[:], baseDigest[:4])
+		return digest, nil
+	}
+
+	activationEpoch, maxBlobsPerBlock := blobParametersAtEpoch(spec, epoch)
+	var params [16]byte
+	binary.LittleEndian.PutUint64(params[:8], activationEpoch)
+	binary.LittleEndian.PutUint64(params[8:], maxBlobsPerBlock)
+	paramsHash := sha256.Sum256(params[:])
+	for i := range digest {
+		digest[i] = baseDigest[i] ^ paramsHash[i]
+	}
+	return digest, nil
+}
+
+// ComputeDomain computes the signature domain of a domain type at a given epoch
+// This is equivalent to the Python spec function:
+//
+//	def get_domain(state: BeaconState, domain_type: DomainType, epoch: Epoch) -> Domain:
+//	    fork_version = state.fork.previous_version if epoch < state.fork.epoch else state.fork.current_version
+//	    return compute_domain(domain_type, fork_version, state.genesis_validators_root)
+//
+// Parameters:
+//   - spec: the spec from GetSpec, providing the fork versions and epochs
+//   - genesis: the genesis data from GetGenesis
+//   - domainType: the domain type, e.g. zrntcommon.DOMAIN_BEACON_PROPOSER
+//   - epoch: the epoch of the signed message
+//
+// Note: voluntary exits are signed with the Capella fork version from Deneb on (EIP-7044),
+// which requires passing an epoch of the Capella fork
+func ComputeDomain(spec *Spec, genesis *GenesisData, domainType zrntcommon.BLSDomainType, epoch uint64) (zrntcommon.BLSDomain, error) {
+	if spec == nil {
+		return zrntcommon.BLSDomain{}, fmt.Errorf("spec is required to compute domains")
+	}
+	genesisValidatorsRoot, err := genesis.validatorsRoot()
+	if err != nil {
+		return zrntcommon.BLSDomain{}, err
+	}
+	current, _ := scheduledForkAtEpoch(spec, epoch)
+	return zrntcommon.ComputeDomain(domainType, current.forkVersion, genesisValidatorsRoot), nil
+}
+
+// ComputeSigningRoot computes the root that is signed for an object in a given domain
+// This is equivalent to the Python spec function:
+//
+//	def compute_signing_root(ssz_object: SSZObject, domain: Domain) -> Root:
+//	    return hash_tree_root(SigningData(object_root=hash_tree_root(ssz_object), domain=domain))
+//
+// Parameters:
+//   - objectRoot: the hash tree root of the signed object, e.g. a block root
+//   - domain: the domain from ComputeDomain
+func ComputeSigningRoot(objectRoot common.Hash, domain zrntcommon.BLSDomain) common.Hash {
+	return common.Hash(zrntcommon.ComputeSigningRoot(zrntcommon.Root(objectRoot), domain))
+}
+
+// IsValidMerkleBranch checks a Merkle branch proving a leaf at index of a tree of depth against root
+// This is equivalent to the Python spec function:
+//
+//	def is_valid_merkle_branch(leaf: Bytes32, branch: Sequence[Bytes32], depth: uint64, index: uint64, root: Root) -> bool:
+//	    value = leaf
+//	    for i in range(depth):
+//	        if index // (2**i) % 2:
+//	            value = hash(branch[i] + value)
+//	        else:
+//	            value = hash(value + branch[i])
+//	    return value == root
+//
+// Parameters:
+//   - leaf: the proven leaf
+//   - branch: the sibling nodes from the leaf up to the root
+//   - depth: the depth of the tree, a branch shorter than depth is invalid
+//   - index: the index of the leaf among the nodes at depth
+//   - root: the expected root
+func IsValidMerkleBranch(leaf common.Hash, branch []common.Hash, depth, index uint64, root common.Hash) bool {
+	if uint64(len(branch)) < depth {
+		return false
+	}
+
+	value := leaf
+	var buf [64]byte
+	for i := range depth {
+		if (index>>i)&1 == 1 {
+			copy(buf[:32], branch[i][:])
+			copy(buf[32:], value[:])
+		} else {
+			copy(buf[:32], value[:])
+			copy(buf[32:], branch[i][:])
+		}
+		value = sha256.Sum256(buf[:])
+	}
+	return value == root
+}
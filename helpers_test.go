@@ -1,6 +1,14 @@
 package beaconclient
 
-import "testing"
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/configs"
+)
 
 func TestComputeTimestampAtSlot(t *testing.T) {
 	tests := []struct {
@@ -93,6 +101,13 @@ func TestComputeSlotAtTimestamp(t *testing.T) {
 			secondsPerSlot: 12,
 			want:           100,
 		},
+		{
+			name:           "zero seconds per slot",
+			genesisTime:    1606824023,
+			timestamp:      1606825223,
+			secondsPerSlot: 0,
+			want:           0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -118,3 +133,317 @@ func TestComputeTimestampAndSlotRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+// newMainnetSpec returns the mainnet spec including the Electra and Fulu schedule
+func newMainnetSpec() *Spec {
+	spec := &Spec{Spec: *configs.Mainnet}
+	spec.ELECTRA_FORK_EPOCH = 364032
+	spec.FULU_FORK_EPOCH = 411392
+	spec.BLOB_SCHEDULE = []BlobScheduleEntry{
+		{Epoch: 419072, MaxBlobsPerBlock: 21},
+		{Epoch: 412672, MaxBlobsPerBlock: 15},
+	}
+	return spec
+}
+
+func TestForkAtEpoch(t *testing.T) {
+	spec := newMainnetSpec()
+	tests := []struct {
+		name  string
+		epoch uint64
+		want  Fork
+	}{
+		{
+			name:  "genesis",
+			epoch: 0,
+			want:  Fork{PreviousVersion: spec.GENESIS_FORK_VERSION, CurrentVersion: spec.GENESIS_FORK_VERSION, Epoch: 0},
+		},
+		{
+			name:  "altair fork epoch",
+			epoch: 74240,
+			want:  Fork{PreviousVersion: spec.GENESIS_FORK_VERSION, CurrentVersion: spec.ALTAIR_FORK_VERSION, Epoch: 74240},
+		},
+		{
+			name:  "capella",
+			epoch: 200000,
+			want:  Fork{PreviousVersion: spec.BELLATRIX_FORK_VERSION, CurrentVersion: spec.CAPELLA_FORK_VERSION, Epoch: 194048},
+		},
+		{
+			name:  "last electra epoch",
+			epoch: 411391,
+			want:  Fork{PreviousVersion: spec.DENEB_FORK_VERSION, CurrentVersion: spec.ELECTRA_FORK_VERSION, Epoch: 364032},
+		},
+		{
+			name:  "fulu",
+			epoch: 500000,
+			want:  Fork{PreviousVersion: spec.ELECTRA_FORK_VERSION, CurrentVersion: spec.FULU_FORK_VERSION, Epoch: 411392},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ForkAtEpoch(spec, tt.epoch); got != tt.want {
+				t.Errorf("ForkAtEpoch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeForkDigest(t *testing.T) {
+	spec := newMainnetSpec()
+	genesis := &GenesisData{GenesisValidatorsRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"}
+
+	tests := []struct {
+		name  string
+		epoch uint64
+		want  string
+	}{
+		{name: "phase0", epoch: 0, want: "0xb5303f2a"},
+		{name: "altair", epoch: 74240, want: "0xafcaaba0"},
+		{name: "bellatrix", epoch: 144896, want: "0x4a26c58b"},
+		{name: "capella", epoch: 194048, want: "0xbba4da96"},
+		{name: "deneb", epoch: 269568, want: "0x6a95a1a9"},
+		{name: "electra", epoch: 364032, want: "0xad532ceb"},
+		// Fulu digests mix in the blob parameters active at the epoch
+		{name: "fulu with electra blob parameters", epoch: 411392, want: "0xcc2c5cdb"},
+		{name: "first blob parameter change", epoch: 412672, want: "0xcb0d1acc"},
+		{name: "within first blob parameter change", epoch: 419071, want: "0xcb0d1acc"},
+		{name: "second blob parameter change", epoch: 419072, want: "0x8c9f62fe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComputeForkDigest(spec, genesis, tt.epoch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ComputeForkDigest() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ComputeForkDigest(spec, &GenesisData{GenesisValidatorsRoot: "0x"}, 0); err == nil {
+		t.Error("expected error for an invalid genesis validators root")
+	}
+}
+
+func TestComputeDomainAndSigningRoot(t *testing.T) {
+	spec := newMainnetSpec()
+	genesis := &GenesisData{GenesisValidatorsRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"}
+
+	domain, err := ComputeDomain(spec, genesis, zrntcommon.DOMAIN_BEACON_PROPOSER, 300000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := zrntcommon.ComputeDomain(zrntcommon.DOMAIN_BEACON_PROPOSER, spec.DENEB_FORK_VERSION,
+		zrntcommon.Root(common.HexToHash(genesis.GenesisValidatorsRoot)))
+	if domain != want {
+		t.Errorf("ComputeDomain() = %s, want %s", domain, want)
+	}
+	if domain[0] != 0 || domain[1] != 0 || domain[2] != 0 || domain[3] != 0 {
+		t.Errorf("expected proposer domain type prefix, got %s", domain)
+	}
+
+	objectRoot := common.Hash{0x01}
+	signingRoot := ComputeSigningRoot(objectRoot, domain)
+	if signingRoot == objectRoot || signingRoot == (common.Hash{}) {
+		t.Errorf("unexpected signing root %s", signingRoot)
+	}
+	otherDomain, _ := ComputeDomain(spec, genesis, zrntcommon.DOMAIN_BEACON_ATTESTER, 300000)
+	if ComputeSigningRoot(objectRoot, otherDomain) == signingRoot {
+		t.Error("expected different signing roots for different domains")
+	}
+}
+
+func TestEpochAndPeriodHelpers(t *testing.T) {
+	spec := newMainnetSpec()
+
+	if got := ComputeEpochAtSlot(spec, 13410020); got != 419063 {
+		t.Errorf("ComputeEpochAtSlot() = %d, want 419063", got)
+	}
+	if got := ComputeStartSlotAtEpoch(spec, 419063); got != 13410016 {
+		t.Errorf("ComputeStartSlotAtEpoch() = %d, want 13410016", got)
+	}
+	if got := ComputeEndSlotAtEpoch(spec, 419063); got != 13410047 {
+		t.Errorf("ComputeEndSlotAtEpoch() = %d, want 13410047", got)
+	}
+	if got := ComputeSyncCommitteePeriod(spec, 419063); got != 1636 {
+		t.Errorf("ComputeSyncCommitteePeriod() = %d, want 1636", got)
+	}
+	if got := ComputeSyncCommitteePeriodAtSlot(spec, 13410020); got != 1636 {
+		t.Errorf("ComputeSyncCommitteePeriodAtSlot() = %d, want 1636", got)
+	}
+	if got := ComputeStartEpochAtSyncCommitteePeriod(spec, 1636); got != 418816 {
+		t.Errorf("ComputeStartEpochAtSyncCommitteePeriod() = %d, want 418816", got)
+	}
+
+	empty := &Spec{}
+	if ComputeEpochAtSlot(empty, 100) != 0 || ComputeSyncCommitteePeriod(empty, 100) != 0 {
+		t.Error("expected zero for an empty spec")
+	}
+	if ComputeStartSlotAtEpoch(empty, 100) != 0 || ComputeEndSlotAtEpoch(empty, 100) != 0 ||
+		ComputeStartEpochAtSyncCommitteePeriod(empty, 100) != 0 {
+		t.Error("expected zero for an empty spec")
+	}
+}
+
+func TestConsensusVersionAtSlot(t *testing.T) {
+	spec := newMainnetSpec()
+	tests := []struct {
+		slot uint64
+		want ConsensusVersion
+	}{
+		{slot: 0, want: ConsensusVersionPhase0},
+		{slot: 74240*32 - 1, want: ConsensusVersionPhase0},
+		{slot: 74240 * 32, want: ConsensusVersionAltair},
+		{slot: 4700013, want: ConsensusVersionBellatrix},
+		{slot: 6209536, want: ConsensusVersionCapella},
+		{slot: 11511320, want: ConsensusVersionDeneb},
+		{slot: 11982020, want: ConsensusVersionElectra},
+		{slot: 13410020, want: ConsensusVersionFulu},
+	}
+
+	for _, tt := range tests {
+		if got := ConsensusVersionAtSlot(spec, tt.slot); got != tt.want {
+			t.Errorf("ConsensusVersionAtSlot(%d) = %s, want %s", tt.slot, got, tt.want)
+		}
+	}
+
+	// unscheduled forks are never active
+	if got := ConsensusVersionAtSlot(&Spec{Spec: *configs.Mainnet}, 13410020); got != ConsensusVersionDeneb {
+		t.Errorf("expected deneb without an electra schedule, got %s", got)
+	}
+}
+
+func TestForkBoundaries(t *testing.T) {
+	spec := newMainnetSpec()
+
+	epoch, err := ForkEpoch(spec, ConsensusVersionElectra)
+	if err != nil || epoch != 364032 {
+		t.Errorf("ForkEpoch(electra) = %d, %v", epoch, err)
+	}
+	if _, err := ForkEpoch(spec, "unknown_version"); err == nil {
+		t.Error("expected error for an unknown version")
+	}
+
+	if !IsForkBoundary(spec, 411392*32) {
+		t.Error("expected the first fulu slot to be a fork boundary")
+	}
+	if IsForkBoundary(spec, 411392*32+1) || IsForkBoundary(spec, 411393*32) {
+		t.Error("expected no fork boundary")
+	}
+	if IsForkBoundary(spec, 0) {
+		t.Error("genesis is not a fork boundary on mainnet")
+	}
+}
+
+func TestHelpers_NilSpec(t *testing.T) {
+	if ComputeEpochAtSlot(nil, 100) != 0 || ComputeStartSlotAtEpoch(nil, 100) != 0 || ComputeEndSlotAtEpoch(nil, 100) != 0 {
+		t.Error("expected zero slots and epochs for a nil spec")
+	}
+	if ComputeSyncCommitteePeriodAtSlot(nil, 100) != 0 || ComputeStartEpochAtSyncCommitteePeriod(nil, 100) != 0 {
+		t.Error("expected zero periods for a nil spec")
+	}
+	if got := ConsensusVersionAtSlot(nil, 100); got != "" {
+		t.Errorf("expected an empty version for a nil spec, got %s", got)
+	}
+	if IsForkBoundary(nil, 0) {
+		t.Error("expected no fork boundary for a nil spec")
+	}
+	if got := ForkAtEpoch(nil, 100); got != (Fork{}) {
+		t.Errorf("expected an empty fork for a nil spec, got %+v", got)
+	}
+	if _, err := ForkEpoch(nil, ConsensusVersionElectra); err == nil {
+		t.Error("expected error for a nil spec in ForkEpoch")
+	}
+
+	genesis := &GenesisData{GenesisValidatorsRoot: common.Hash{}.Hex()}
+	if _, err := ComputeForkDigest(nil, genesis, 100); err == nil {
+		t.Error("expected error for a nil spec in ComputeForkDigest")
+	}
+	if _, err := ComputeDomain(nil, genesis, zrntcommon.DOMAIN_BEACON_PROPOSER, 100); err == nil {
+		t.Error("expected error for a nil spec in ComputeDomain")
+	}
+}
+
+func TestIsValidMerkleBranch(t *testing.T) {
+	leaf := common.Hash{0x01}
+	tree := testMerkleTree{executionPayloadGindex: leaf}
+	branch := tree.branch(executionPayloadGindex)
+	root := tree.root()
+
+	if !IsValidMerkleBranch(leaf, branch, 4, 9, root) {
+		t.Error("expected valid branch")
+	}
+	if IsValidMerkleBranch(leaf, branch, 4, 8, root) {
+		t.Error("expected invalid branch for another index")
+	}
+	if IsValidMerkleBranch(common.Hash{0x02}, branch, 4, 9, root) {
+		t.Error("expected invalid branch for another leaf")
+	}
+	if IsValidMerkleBranch(leaf, branch[:3], 4, 9, root) {
+		t.Error("expected invalid branch for a short branch")
+	}
+
+	// normalized branches may be padded with zero hashes
+	padded := append([]common.Hash{{}}, branch...)
+	if !isValidNormalizedMerkleBranch(leaf, padded, executionPayloadGindex, root) {
+		t.Error("expected valid normalized branch")
+	}
+	padded[0] = common.Hash{0x01}
+	if isValidNormalizedMerkleBranch(leaf, padded, executionPayloadGindex, root) {
+		t.Error("expected invalid normalized branch with non-zero padding")
+	}
+}
+
+func TestForkSchedule_UnknownFork(t *testing.T) {
+	// an Electra node does not know Fulu and omits its keys
+	data := []byte(`{"data":{
+		"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32",
+		"GENESIS_FORK_VERSION":"0x00000000",
+		"ALTAIR_FORK_VERSION":"0x01000000","ALTAIR_FORK_EPOCH":"74240",
+		"BELLATRIX_FORK_VERSION":"0x02000000","BELLATRIX_FORK_EPOCH":"144896",
+		"CAPELLA_FORK_VERSION":"0x03000000","CAPELLA_FORK_EPOCH":"194048",
+		"DENEB_FORK_VERSION":"0x04000000","DENEB_FORK_EPOCH":"269568",
+		"ELECTRA_FORK_VERSION":"0x05000000","ELECTRA_FORK_EPOCH":"364032"
+	}}`)
+	var resp specResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("failed to decode spec: %v", err)
+	}
+	spec := resp.Data
+
+	if epoch, err := ForkEpoch(spec, ConsensusVersionFulu); err != nil || epoch != math.MaxUint64 {
+		t.Errorf("expected unscheduled fulu, got %d, %v", epoch, err)
+	}
+	if version := ConsensusVersionAtEpoch(spec, 500000); version != ConsensusVersionElectra {
+		t.Errorf("expected electra, got %s", version)
+	}
+	if version := ConsensusVersionAtEpoch(spec, 0); version != ConsensusVersionPhase0 {
+		t.Errorf("expected phase0 at genesis, got %s", version)
+	}
+	fork := ForkAtEpoch(spec, 500000)
+	if fork.CurrentVersion != spec.ELECTRA_FORK_VERSION || fork.Epoch != 364032 {
+		t.Errorf("unexpected fork: %+v", fork)
+	}
+
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+	electraDigest, err := ComputeForkDigest(spec, genesis, 364032)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	digest, err := ComputeForkDigest(spec, genesis, 500000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if digest != electraDigest {
+		t.Errorf("expected the electra digest %x, got %x", electraDigest, digest)
+	}
+
+	// a fork at genesis is still scheduled when its fork version is known
+	spec.FULU_FORK_VERSION = zrntcommon.Version{0x06}
+	if epoch, _ := ForkEpoch(spec, ConsensusVersionFulu); epoch != 0 {
+		t.Errorf("expected fulu at genesis, got %d", epoch)
+	}
+}
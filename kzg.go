@@ -0,0 +1,140 @@
+package beaconclient
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/tree"
+)
+
+const (
+	// blobKZGCommitmentsGindex is the generalized index of blob_kzg_commitments in BeaconBlockBody
+	blobKZGCommitmentsGindex = 27
+	// maxBlobCommitmentsPerBlock is MAX_BLOB_COMMITMENTS_PER_BLOCK of the mainnet preset
+	maxBlobCommitmentsPerBlock = 4096
+	// blobKZGCommitmentsDepth is the depth of the blob_kzg_commitments list data
+	blobKZGCommitmentsDepth = 12
+	// kzgCommitmentInclusionProofDepth is KZG_COMMITMENT_INCLUSION_PROOF_DEPTH of the mainnet preset:
+	// the body fields, the length mix-in of the list and the list data
+	kzgCommitmentInclusionProofDepth = 4 + 1 + blobKZGCommitmentsDepth
+)
+
+// WithBlobVerification makes GetBlobs and GetBlobSidecars verify the returned blobs locally
+// with KZG before returning them, see VerifyBlobs and VerifyBlobSidecars
+//
+// GetBlobs responses carry no commitments or proofs, so blobs requested without versioned
+// hashes are only checked to be well formed, not that they belong to the block.
+func WithBlobVerification() Option {
+	return func(c *Client) {
+		c.verifyBlobs = true
+	}
+}
+
+// BlobVerificationError reports a blob that failed local KZG verification
+type BlobVerificationError struct {
+	// Index is the blob index of a sidecar, or the position of the blob in a GetBlobs response.
+	// For a missing blob it is the position of VersionedHash in the requested versioned hashes.
+	Index uint64
+	// VersionedHash is the unexpected or missing versioned hash, if the check was against versioned hashes
+	VersionedHash common.Hash
+	// Reason describes the failed check
+	Reason string
+	// Err is the underlying KZG error, if any
+	Err error
+}
+
+func (e *BlobVerificationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("blob %d failed verification: %s: %v", e.Index, e.Reason, e.Err)
+	}
+	return fmt.Sprintf("blob %d failed verification: %s", e.Index, e.Reason)
+}
+
+func (e *BlobVerificationError) Unwrap() error {
+	return e.Err
+}
+
+// VerifyBlobs recomputes the KZG commitments of the blobs and checks their versioned hashes
+//
+// If versionedHashes is empty, only the validity of the blobs is checked: no commitment is
+// compared, so any well formed blob passes. Otherwise every
+// blob must match one of the versioned hashes and every versioned hash must be matched,
+// as expected from GetBlobs(ctx, blockID, versionedHashes...). Duplicate versioned hashes
+// are requested only once, and a block carrying the same blob twice returns it twice.
+func VerifyBlobs(blobs []kzg4844.Blob, versionedHashes ...common.Hash) error {
+	matched := make(map[common.Hash]bool, len(versionedHashes))
+	for _, hash := range versionedHashes {
+		matched[hash] = false
+	}
+
+	hasher := sha256.New()
+	for i := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(&blobs[i])
+		if err != nil {
+			return &BlobVerificationError{Index: uint64(i), Reason: "invalid blob", Err: err}
+		}
+		if len(versionedHashes) == 0 {
+			continue
+		}
+
+		hash := common.Hash(kzg4844.CalcBlobHashV1(hasher, &commitment))
+		if _, ok := matched[hash]; !ok {
+			return &BlobVerificationError{Index: uint64(i), VersionedHash: hash, Reason: fmt.Sprintf("unexpected versioned hash %s", hash.Hex())}
+		}
+		matched[hash] = true
+	}
+
+	// report the first missing hash in request order
+	for i, hash := range versionedHashes {
+		if !matched[hash] {
+			return &BlobVerificationError{Index: uint64(i), VersionedHash: hash, Reason: fmt.Sprintf("missing blob for versioned hash %s", hash.Hex())}
+		}
+	}
+	return nil
+}
+
+// VerifyBlobSidecars checks that the commitment of every sidecar matches its blob,
+// verifies the KZG proof of the blob against the commitment and the inclusion proof
+// of the commitment against the body root of the block header
+//
+// All sidecars must carry the same block header. The header itself, including its
+// signature, is not verified here, see VerifyBlockHeader and VerifyProposerSignature.
+// Inclusion proofs are checked for the mainnet preset.
+func VerifyBlobSidecars(sidecars []BlobSidecar) error {
+	for i := range sidecars {
+		sidecar := &sidecars[i]
+		if sidecar.SignedBlockHeader != sidecars[0].SignedBlockHeader {
+			return &BlobVerificationError{Index: sidecar.Index, Reason: "block header differs from the other sidecars"}
+		}
+		commitment, err := kzg4844.BlobToCommitment(&sidecar.Blob)
+		if err != nil {
+			return &BlobVerificationError{Index: sidecar.Index, Reason: "invalid blob", Err: err}
+		}
+		if commitment != sidecar.KZGCommitment {
+			return &BlobVerificationError{Index: sidecar.Index, Reason: "commitment does not match blob"}
+		}
+		if err := kzg4844.VerifyBlobProof(&sidecar.Blob, sidecar.KZGCommitment, sidecar.KZGProof); err != nil {
+			return &BlobVerificationError{Index: sidecar.Index, Reason: "invalid blob proof", Err: err}
+		}
+		if !verifyBlobSidecarInclusionProof(sidecar) {
+			return &BlobVerificationError{Index: sidecar.Index, Reason: "invalid commitment inclusion proof"}
+		}
+	}
+	return nil
+}
+
+// verifyBlobSidecarInclusionProof is equivalent to the Python spec function verify_blob_sidecar_inclusion_proof
+func verifyBlobSidecarInclusionProof(sidecar *BlobSidecar) bool {
+	if sidecar.Index >= maxBlobCommitmentsPerBlock || len(sidecar.KZGCommitmentInclusionProof) != kzgCommitmentInclusionProofDepth {
+		return false
+	}
+
+	// blob_kzg_commitments[index] lies in the list data, the left child of the list root
+	gindex := (blobKZGCommitmentsGindex*2)<<blobKZGCommitmentsDepth | sidecar.Index
+	leaf := common.Hash(zrntcommon.KZGCommitment(sidecar.KZGCommitment).HashTreeRoot(tree.GetHashFn()))
+	return IsValidMerkleBranch(leaf, sidecar.KZGCommitmentInclusionProof, kzgCommitmentInclusionProofDepth,
+		gindex%(1<<kzgCommitmentInclusionProofDepth), sidecar.SignedBlockHeader.Message.BodyRoot)
+}
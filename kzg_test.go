@@ -0,0 +1,218 @@
+package beaconclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+func testVersionedHash(t *testing.T, blob *kzg4844.Blob) common.Hash {
+	t.Helper()
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	if err != nil {
+		t.Fatalf("failed to compute commitment: %v", err)
+	}
+	return common.Hash(kzg4844.CalcBlobHashV1(sha256.New(), &commitment))
+}
+
+func TestVerifyBlobs(t *testing.T) {
+	blobs := loadTestBlobs(t)[:2]
+	hash0 := testVersionedHash(t, &blobs[0])
+	hash1 := testVersionedHash(t, &blobs[1])
+
+	if err := VerifyBlobs(blobs); err != nil {
+		t.Errorf("unexpected error without hashes: %v", err)
+	}
+	if err := VerifyBlobs(blobs, hash1, hash0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := VerifyBlobs(blobs, hash0, common.Hash{0x01})
+	var verr *BlobVerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *BlobVerificationError, got %T: %v", err, err)
+	}
+	if verr.Index != 1 {
+		t.Errorf("expected index 1, got %d", verr.Index)
+	}
+
+	if err := VerifyBlobs(blobs, hash0); err == nil {
+		t.Error("expected error for unexpected blob count")
+	}
+	if err := VerifyBlobs(blobs, hash0, hash0); !errors.As(err, &verr) || verr.Index != 1 || verr.VersionedHash != hash1 {
+		t.Errorf("expected error for the unexpected second blob, got %v", err)
+	}
+	if err := VerifyBlobs(blobs[:1], hash0, hash1); !errors.As(err, &verr) || verr.Index != 1 || verr.VersionedHash != hash1 {
+		t.Errorf("expected error for the missing second blob, got %v", err)
+	}
+}
+
+func TestVerifyBlobs_DuplicateHashes(t *testing.T) {
+	blobs := loadTestBlobs(t)[:2]
+	hash0 := testVersionedHash(t, &blobs[0])
+	hash1 := testVersionedHash(t, &blobs[1])
+
+	// the node answers a duplicate versioned hash with a single blob
+	if err := VerifyBlobs(blobs[:1], hash0, hash0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := VerifyBlobs(blobs, hash1, hash0, hash1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// a block may carry the same blob twice
+	if err := VerifyBlobs([]kzg4844.Blob{blobs[0], blobs[0]}, hash0, hash0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifyBlobs_InvalidBlob(t *testing.T) {
+	blobs := loadTestBlobs(t)[:2]
+	// a field element above the BLS modulus is not a valid blob
+	for i := 0; i < 32; i++ {
+		blobs[1][i] = 0xff
+	}
+
+	err := VerifyBlobs(blobs)
+	var verr *BlobVerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *BlobVerificationError, got %T: %v", err, err)
+	}
+	if verr.Index != 1 || verr.Err == nil {
+		t.Errorf("unexpected error: %v", verr)
+	}
+}
+
+func TestVerifyBlobSidecars(t *testing.T) {
+	blobs := loadTestBlobs(t)
+	sidecars := newTestBlobSidecars(t, blobs, 0, 3)
+	if err := VerifyBlobSidecars(sidecars); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		mutate     func(sidecar *BlobSidecar)
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:       "commitment mismatch",
+			mutate:     func(sidecar *BlobSidecar) { sidecar.KZGCommitment = sidecars[0].KZGCommitment },
+			wantReason: "commitment does not match blob",
+		},
+		{
+			name:       "proof of another blob",
+			mutate:     func(sidecar *BlobSidecar) { sidecar.KZGProof = sidecars[0].KZGProof },
+			wantReason: "invalid blob proof",
+			wantErr:    true,
+		},
+		{
+			name: "tampered inclusion proof",
+			mutate: func(sidecar *BlobSidecar) {
+				sidecar.KZGCommitmentInclusionProof = slices.Clone(sidecar.KZGCommitmentInclusionProof)
+				sidecar.KZGCommitmentInclusionProof[0][0] ^= 1
+			},
+			wantReason: "invalid commitment inclusion proof",
+		},
+		{
+			name: "inclusion proof of another index",
+			mutate: func(sidecar *BlobSidecar) {
+				sidecar.KZGCommitmentInclusionProof = sidecars[0].KZGCommitmentInclusionProof
+			},
+			wantReason: "invalid commitment inclusion proof",
+		},
+		{
+			name:       "header of another block",
+			mutate:     func(sidecar *BlobSidecar) { sidecar.SignedBlockHeader.Message.Slot++ },
+			wantReason: "block header differs from the other sidecars",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forged := append([]BlobSidecar(nil), sidecars...)
+			tt.mutate(&forged[1])
+
+			err := VerifyBlobSidecars(forged)
+			var verr *BlobVerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected *BlobVerificationError, got %T: %v", err, err)
+			}
+			if verr.Index != 3 {
+				t.Errorf("expected blob index 3, got %d", verr.Index)
+			}
+			if verr.Reason != tt.wantReason {
+				t.Errorf("expected reason %q, got %q", tt.wantReason, verr.Reason)
+			}
+			if (verr.Err != nil) != tt.wantErr {
+				t.Errorf("unexpected underlying error: %v", verr.Err)
+			}
+		})
+	}
+}
+
+func TestGetBlobs_Verification(t *testing.T) {
+	blobs := loadTestBlobs(t)[:2]
+	hash0 := testVersionedHash(t, &blobs[0])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(BlobsData{Data: blobs[1:]})
+		if err != nil {
+			t.Fatalf("failed to encode blobs: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	// without verification the wrong blob is returned as-is
+	if _, err := NewClient(server.URL).GetBlobs(context.Background(), "head", hash0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient(server.URL, WithBlobVerification())
+	_, err := client.GetBlobs(context.Background(), "head", hash0)
+	var verr *BlobVerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *BlobVerificationError, got %T: %v", err, err)
+	}
+	if verr.Index != 0 {
+		t.Errorf("expected index 0, got %d", verr.Index)
+	}
+}
+
+func TestGetBlobSidecars_Verification(t *testing.T) {
+	blobs := loadTestBlobs(t)
+	sidecars := newTestBlobSidecars(t, blobs, 0, 1)
+	sidecars[1].KZGProof = sidecars[0].KZGProof
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(BlobSidecarsResponse{Data: sidecars})
+		if err != nil {
+			t.Fatalf("failed to encode sidecars: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithBlobVerification())
+	_, err := client.GetBlobSidecars(context.Background(), "head")
+	var verr *BlobVerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *BlobVerificationError, got %T: %v", err, err)
+	}
+	if verr.Index != 1 {
+		t.Errorf("expected index 1, got %d", verr.Index)
+	}
+}
@@ -0,0 +1,600 @@
+package beaconclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/tree"
+	"github.com/protolambda/ztyp/view"
+)
+
+// The light client containers exist from Altair on. Their SSZ shape depends on the fork:
+//   - Altair and Bellatrix headers only contain the beacon block header
+//   - Capella headers add the execution payload header and its Merkle branch
+//   - Deneb headers use the Deneb execution payload header, which Electra and Fulu kept
+//   - Electra deepened the sync committee and finality branches, as the state got more fields
+
+const (
+	// executionBranchDepth is floorlog2(EXECUTION_PAYLOAD_GINDEX)
+	executionBranchDepth = 4
+)
+
+// lightClientFork describes the light client container shapes of a consensus version
+type lightClientFork struct {
+	version ConsensusVersion
+	// syncCommitteeBranchDepth is floorlog2 of the current and next sync committee gindices
+	syncCommitteeBranchDepth uint64
+	// finalityBranchDepth is floorlog2(FINALIZED_ROOT_GINDEX)
+	finalityBranchDepth uint64
+}
+
+// lightClientForkOf returns the light client container shapes of a consensus version
+func lightClientForkOf(version ConsensusVersion) (lightClientFork, error) {
+	switch version {
+	case ConsensusVersionAltair, ConsensusVersionBellatrix, ConsensusVersionCapella, ConsensusVersionDeneb:
+		return lightClientFork{version: version, syncCommitteeBranchDepth: 5, finalityBranchDepth: 6}, nil
+	case ConsensusVersionElectra, ConsensusVersionFulu:
+		return lightClientFork{version: version, syncCommitteeBranchDepth: 6, finalityBranchDepth: 7}, nil
+	default:
+		return lightClientFork{}, fmt.Errorf("unsupported light client version: %s", version)
+	}
+}
+
+// newExecutionHeader returns an empty execution payload header of the fork, or nil before Capella
+func (f lightClientFork) newExecutionHeader() *ExecutionPayloadHeader {
+	switch f.version {
+	case ConsensusVersionAltair, ConsensusVersionBellatrix:
+		return nil
+	case ConsensusVersionCapella:
+		return &ExecutionPayloadHeader{Capella: new(capella.ExecutionPayloadHeader)}
+	default:
+		return &ExecutionPayloadHeader{Deneb: new(deneb.ExecutionPayloadHeader)}
+	}
+}
+
+// ExecutionPayloadHeader is the execution payload header of a light client header
+// Capella is set for Capella headers, Deneb for Deneb and later headers, which did not change it
+type ExecutionPayloadHeader struct {
+	Capella *capella.ExecutionPayloadHeader
+	Deneb   *deneb.ExecutionPayloadHeader
+}
+
+// MarshalJSON encodes the header of the fork that is set
+func (h ExecutionPayloadHeader) MarshalJSON() ([]byte, error) {
+	switch {
+	case h.Capella != nil:
+		return json.Marshal(h.Capella)
+	case h.Deneb != nil:
+		return json.Marshal(h.Deneb)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// UnmarshalJSON decodes a Deneb header if it has blob gas fields, otherwise a Capella header
+func (h *ExecutionPayloadHeader) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		BlobGasUsed json.RawMessage `json:"blob_gas_used"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	*h = ExecutionPayloadHeader{}
+	if probe.BlobGasUsed != nil {
+		h.Deneb = new(deneb.ExecutionPayloadHeader)
+		return json.Unmarshal(data, h.Deneb)
+	}
+	h.Capella = new(capella.ExecutionPayloadHeader)
+	return json.Unmarshal(data, h.Capella)
+}
+
+// BlockHash returns the execution block hash
+func (h *ExecutionPayloadHeader) BlockHash() common.Hash {
+	switch {
+	case h.Capella != nil:
+		return common.Hash(h.Capella.BlockHash)
+	case h.Deneb != nil:
+		return common.Hash(h.Deneb.BlockHash)
+	default:
+		return common.Hash{}
+	}
+}
+
+// BlockNumber returns the execution block number
+func (h *ExecutionPayloadHeader) BlockNumber() uint64 {
+	switch {
+	case h.Capella != nil:
+		return uint64(h.Capella.BlockNumber)
+	case h.Deneb != nil:
+		return uint64(h.Deneb.BlockNumber)
+	default:
+		return 0
+	}
+}
+
+// StateRoot returns the execution state root
+func (h *ExecutionPayloadHeader) StateRoot() common.Hash {
+	switch {
+	case h.Capella != nil:
+		return common.Hash(h.Capella.StateRoot)
+	case h.Deneb != nil:
+		return common.Hash(h.Deneb.StateRoot)
+	default:
+		return common.Hash{}
+	}
+}
+
+// HashTreeRoot returns the hash tree root of the header
+func (h *ExecutionPayloadHeader) HashTreeRoot() common.Hash {
+	switch {
+	case h.Capella != nil:
+		return common.Hash(h.Capella.HashTreeRoot(tree.GetHashFn()))
+	case h.Deneb != nil:
+		return common.Hash(h.Deneb.HashTreeRoot(tree.GetHashFn()))
+	default:
+		return common.Hash{}
+	}
+}
+
+func (h *ExecutionPayloadHeader) field() (codec.Deserializable, error) {
+	switch {
+	case h.Capella != nil:
+		return h.Capella, nil
+	case h.Deneb != nil:
+		return h.Deneb, nil
+	default:
+		return nil, fmt.Errorf("empty execution payload header")
+	}
+}
+
+// LightClientHeader is the header of a beacon block as seen by light clients
+type LightClientHeader struct {
+	// Beacon is the beacon block header
+	Beacon BeaconBlockHeader `json:"beacon"`
+	// Execution is the execution payload header of the block, from Capella on
+	Execution *ExecutionPayloadHeader `json:"execution,omitempty"`
+	// ExecutionBranch is the Merkle proof of Execution against the beacon block body root
+	ExecutionBranch []common.Hash `json:"execution_branch,omitempty"`
+}
+
+// LightClientBootstrap is the data a light client is initialized with from a trusted block root
+type LightClientBootstrap struct {
+	// Header is the header of the trusted block
+	Header LightClientHeader `json:"header"`
+	// CurrentSyncCommittee is the sync committee of the period of the block
+	CurrentSyncCommittee zrntcommon.SyncCommittee `json:"current_sync_committee"`
+	// CurrentSyncCommitteeBranch is the Merkle proof of CurrentSyncCommittee against the block state root
+	CurrentSyncCommitteeBranch []common.Hash `json:"current_sync_committee_branch"`
+}
+
+func (b *LightClientBootstrap) sszFields(spec *zrntcommon.Spec, fork lightClientFork) []codec.Deserializable {
+	return []codec.Deserializable{
+		lightClientHeaderSSZ{&b.Header, fork},
+		spec.Wrap(&b.CurrentSyncCommittee),
+		merkleBranchSSZ{&b.CurrentSyncCommitteeBranch, fork.syncCommitteeBranchDepth},
+	}
+}
+
+// LightClientUpdate is the update a light client advances its sync committee and finalized header with
+type LightClientUpdate struct {
+	// AttestedHeader is the header attested to by the sync committee
+	AttestedHeader LightClientHeader `json:"attested_header"`
+	// NextSyncCommittee is the next sync committee stored in the attested state
+	NextSyncCommittee zrntcommon.SyncCommittee `json:"next_sync_committee"`
+	// NextSyncCommitteeBranch is the Merkle proof of NextSyncCommittee against the attested state root
+	NextSyncCommitteeBranch []common.Hash `json:"next_sync_committee_branch"`
+	// FinalizedHeader is the header finalized in the attested state
+	FinalizedHeader LightClientHeader `json:"finalized_header"`
+	// FinalityBranch is the Merkle proof of the finalized root against the attested state root
+	FinalityBranch []common.Hash `json:"finality_branch"`
+	// SyncAggregate is the sync committee signature over AttestedHeader
+	SyncAggregate altair.SyncAggregate `json:"sync_aggregate"`
+	// SignatureSlot is the slot at which SyncAggregate was included
+	SignatureSlot uint64 `json:"signature_slot,string"`
+}
+
+func (u *LightClientUpdate) sszFields(spec *zrntcommon.Spec, fork lightClientFork) []codec.Deserializable {
+	return []codec.Deserializable{
+		lightClientHeaderSSZ{&u.AttestedHeader, fork},
+		spec.Wrap(&u.NextSyncCommittee),
+		merkleBranchSSZ{&u.NextSyncCommitteeBranch, fork.syncCommitteeBranchDepth},
+		lightClientHeaderSSZ{&u.FinalizedHeader, fork},
+		merkleBranchSSZ{&u.FinalityBranch, fork.finalityBranchDepth},
+		spec.Wrap(&u.SyncAggregate),
+		(*view.Uint64View)(&u.SignatureSlot),
+	}
+}
+
+// LightClientFinalityUpdate is a LightClientUpdate without the next sync committee
+type LightClientFinalityUpdate struct {
+	AttestedHeader  LightClientHeader    `json:"attested_header"`
+	FinalizedHeader LightClientHeader    `json:"finalized_header"`
+	FinalityBranch  []common.Hash        `json:"finality_branch"`
+	SyncAggregate   altair.SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot   uint64               `json:"signature_slot,string"`
+}
+
+func (u *LightClientFinalityUpdate) sszFields(spec *zrntcommon.Spec, fork lightClientFork) []codec.Deserializable {
+	return []codec.Deserializable{
+		lightClientHeaderSSZ{&u.AttestedHeader, fork},
+		lightClientHeaderSSZ{&u.FinalizedHeader, fork},
+		merkleBranchSSZ{&u.FinalityBranch, fork.finalityBranchDepth},
+		spec.Wrap(&u.SyncAggregate),
+		(*view.Uint64View)(&u.SignatureSlot),
+	}
+}
+
+// LightClientOptimisticUpdate is a LightClientUpdate with only the attested header
+type LightClientOptimisticUpdate struct {
+	AttestedHeader LightClientHeader    `json:"attested_header"`
+	SyncAggregate  altair.SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot  uint64               `json:"signature_slot,string"`
+}
+
+func (u *LightClientOptimisticUpdate) sszFields(spec *zrntcommon.Spec, fork lightClientFork) []codec.Deserializable {
+	return []codec.Deserializable{
+		lightClientHeaderSSZ{&u.AttestedHeader, fork},
+		spec.Wrap(&u.SyncAggregate),
+		(*view.Uint64View)(&u.SignatureSlot),
+	}
+}
+
+// LightClientBootstrapResponse represents the response from /eth/v1/beacon/light_client/bootstrap/{block_root}
+type LightClientBootstrapResponse struct {
+	Version ConsensusVersion     `json:"version"`
+	Data    LightClientBootstrap `json:"data"`
+}
+
+// LightClientUpdateResponse represents an update of the response from /eth/v1/beacon/light_client/updates
+type LightClientUpdateResponse struct {
+	Version ConsensusVersion  `json:"version"`
+	Data    LightClientUpdate `json:"data"`
+}
+
+// LightClientFinalityUpdateResponse represents the response from /eth/v1/beacon/light_client/finality_update
+// It is also the data of the light_client_finality_update event
+type LightClientFinalityUpdateResponse struct {
+	Version ConsensusVersion          `json:"version"`
+	Data    LightClientFinalityUpdate `json:"data"`
+}
+
+// LightClientOptimisticUpdateResponse represents the response from /eth/v1/beacon/light_client/optimistic_update
+// It is also the data of the light_client_optimistic_update event
+type LightClientOptimisticUpdateResponse struct {
+	Version ConsensusVersion            `json:"version"`
+	Data    LightClientOptimisticUpdate `json:"data"`
+}
+
+// GetLightClientBootstrap retrieves the light client bootstrap for a trusted block root
+// Endpoint: GET /eth/v1/beacon/light_client/bootstrap/{block_root}
+//
+// The block root should be a finalized checkpoint root, nodes only serve bootstraps for those
+func (c *Client) GetLightClientBootstrap(ctx context.Context, blockRoot common.Hash) (*LightClientBootstrapResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/light_client/bootstrap/"+blockRoot.Hex(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp LightClientBootstrapResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetLightClientBootstrapSSZ retrieves the light client bootstrap for a trusted block root using SSZ encoding
+// Endpoint: GET /eth/v1/beacon/light_client/bootstrap/{block_root}
+//
+// The spec (see GetSpec) provides the sync committee size.
+func (c *Client) GetLightClientBootstrapSSZ(ctx context.Context, spec *Spec, blockRoot common.Hash) (*LightClientBootstrapResponse, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is required to decode SSZ light client data")
+	}
+
+	resp, err := c.doSSZRequest(ctx, http.MethodGet, "/eth/v1/beacon/light_client/bootstrap/"+blockRoot.Hex(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var bootstrap LightClientBootstrapResponse
+	if !resp.isSSZ() {
+		if err := json.Unmarshal(resp.body, &bootstrap); err != nil {
+			return nil, err
+		}
+		return &bootstrap, nil
+	}
+
+	bootstrap.Version = resp.consensusVersion()
+	if err := decodeLightClientSSZ(spec, bootstrap.Version, resp.body, &bootstrap.Data); err != nil {
+		return nil, err
+	}
+	return &bootstrap, nil
+}
+
+// GetLightClientUpdates retrieves the best light client updates of a range of sync committee periods
+// Endpoint: GET /eth/v1/beacon/light_client/updates
+//
+// Updates are returned for the periods startPeriod to startPeriod+count-1, periods without an update are skipped.
+// Nodes cap count at MAX_REQUEST_LIGHT_CLIENT_UPDATES (128).
+func (c *Client) GetLightClientUpdates(ctx context.Context, startPeriod, count uint64) ([]LightClientUpdateResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/light_client/updates", lightClientUpdatesQuery(startPeriod, count))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []LightClientUpdateResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLightClientUpdatesSSZ retrieves the best light client updates of a range of sync committee periods using SSZ encoding
+// Endpoint: GET /eth/v1/beacon/light_client/updates
+//
+// The SSZ response is a sequence of chunks, each made of the 8 byte little endian length of the rest of
+// the chunk, the fork digest of the update and the update itself. The fork digests are resolved with the
+// spec and the genesis validators root, which is fetched with GetGenesis.
+func (c *Client) GetLightClientUpdatesSSZ(ctx context.Context, spec *Spec, startPeriod, count uint64) ([]LightClientUpdateResponse, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is required to decode SSZ light client data")
+	}
+
+	resp, err := c.doSSZRequest(ctx, http.MethodGet, "/eth/v1/beacon/light_client/updates", lightClientUpdatesQuery(startPeriod, count))
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.isSSZ() {
+		var updates []LightClientUpdateResponse
+		if err := json.Unmarshal(resp.body, &updates); err != nil {
+			return nil, err
+		}
+		return updates, nil
+	}
+
+	genesis, err := c.GetGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := lightClientVersionsByDigest(spec, genesis)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLightClientUpdatesSSZ(spec, versions, resp.body)
+}
+
+func lightClientUpdatesQuery(startPeriod, count uint64) url.Values {
+	query := url.Values{}
+	query.Set("start_period", strconv.FormatUint(startPeriod, 10))
+	query.Set("count", strconv.FormatUint(count, 10))
+	return query
+}
+
+// decodeLightClientUpdatesSSZ decodes the response chunks of the updates endpoint
+func decodeLightClientUpdatesSSZ(spec *Spec, versions map[zrntcommon.ForkDigest]ConsensusVersion, data []byte) ([]LightClientUpdateResponse, error) {
+	const chunkPrefixLength = 8
+
+	updates := make([]LightClientUpdateResponse, 0)
+	for len(data) > 0 {
+		if len(data) < chunkPrefixLength {
+			return nil, fmt.Errorf("truncated light client update chunk length")
+		}
+		length := binary.LittleEndian.Uint64(data[:chunkPrefixLength])
+		data = data[chunkPrefixLength:]
+		if length < uint64(len(zrntcommon.ForkDigest{})) || length > uint64(len(data)) {
+			return nil, fmt.Errorf("invalid light client update chunk length %d", length)
+		}
+
+		var digest zrntcommon.ForkDigest
+		copy(digest[:], data)
+		version, ok := versions[digest]
+		if !ok {
+			return nil, fmt.Errorf("unknown fork digest %s of light client update", digest)
+		}
+
+		update := LightClientUpdateResponse{Version: version}
+		if err := decodeLightClientSSZ(spec, version, data[len(digest):length], &update.Data); err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+		data = data[length:]
+	}
+	return updates, nil
+}
+
+// lightClientVersionsByDigest maps the fork digests of the scheduled forks to their consensus versions
+// From Fulu on, every blob parameter change also has its own digest
+func lightClientVersionsByDigest(spec *Spec, genesis *GenesisData) (map[zrntcommon.ForkDigest]ConsensusVersion, error) {
+	epochs := make(map[uint64]ConsensusVersion)
+	for _, fork := range forkSchedule(spec)[1:] {
+		epochs[fork.epoch] = fork.version
+	}
+	for _, entry := range spec.BLOB_SCHEDULE {
+		if epoch := uint64(entry.Epoch); epoch >= scheduledForkEpoch(spec, ConsensusVersionFulu) {
+			epochs[epoch] = ConsensusVersionAtEpoch(spec, epoch)
+		}
+	}
+
+	versions := make(map[zrntcommon.ForkDigest]ConsensusVersion, len(epochs))
+	for epoch, version := range epochs {
+		if epoch == math.MaxUint64 {
+			continue
+		}
+		digest, err := ComputeForkDigest(spec, genesis, epoch)
+		if err != nil {
+			return nil, err
+		}
+		versions[digest] = version
+	}
+	return versions, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update known to the node
+// Endpoint: GET /eth/v1/beacon/light_client/finality_update
+func (c *Client) GetLightClientFinalityUpdate(ctx context.Context) (*LightClientFinalityUpdateResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/light_client/finality_update", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp LightClientFinalityUpdateResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetLightClientFinalityUpdateSSZ retrieves the latest light client finality update known to the node using SSZ encoding
+// Endpoint: GET /eth/v1/beacon/light_client/finality_update
+//
+// The spec (see GetSpec) provides the sync committee size.
+func (c *Client) GetLightClientFinalityUpdateSSZ(ctx context.Context, spec *Spec) (*LightClientFinalityUpdateResponse, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is required to decode SSZ light client data")
+	}
+
+	resp, err := c.doSSZRequest(ctx, http.MethodGet, "/eth/v1/beacon/light_client/finality_update", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var update LightClientFinalityUpdateResponse
+	if !resp.isSSZ() {
+		if err := json.Unmarshal(resp.body, &update); err != nil {
+			return nil, err
+		}
+		return &update, nil
+	}
+
+	update.Version = resp.consensusVersion()
+	if err := decodeLightClientSSZ(spec, update.Version, resp.body, &update.Data); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update known to the node
+// Endpoint: GET /eth/v1/beacon/light_client/optimistic_update
+func (c *Client) GetLightClientOptimisticUpdate(ctx context.Context) (*LightClientOptimisticUpdateResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/light_client/optimistic_update", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp LightClientOptimisticUpdateResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetLightClientOptimisticUpdateSSZ retrieves the latest light client optimistic update known to the node using SSZ encoding
+// Endpoint: GET /eth/v1/beacon/light_client/optimistic_update
+//
+// The spec (see GetSpec) provides the sync committee size.
+func (c *Client) GetLightClientOptimisticUpdateSSZ(ctx context.Context, spec *Spec) (*LightClientOptimisticUpdateResponse, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is required to decode SSZ light client data")
+	}
+
+	resp, err := c.doSSZRequest(ctx, http.MethodGet, "/eth/v1/beacon/light_client/optimistic_update", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var update LightClientOptimisticUpdateResponse
+	if !resp.isSSZ() {
+		if err := json.Unmarshal(resp.body, &update); err != nil {
+			return nil, err
+		}
+		return &update, nil
+	}
+
+	update.Version = resp.consensusVersion()
+	if err := decodeLightClientSSZ(spec, update.Version, resp.body, &update.Data); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
+// lightClientObject is a light client container of which the SSZ fields depend on the fork
+type lightClientObject interface {
+	sszFields(spec *zrntcommon.Spec, fork lightClientFork) []codec.Deserializable
+}
+
+// decodeLightClientSSZ decodes a light client container with the SSZ shape of version
+func decodeLightClientSSZ(spec *Spec, version ConsensusVersion, data []byte, obj lightClientObject) error {
+	fork, err := lightClientForkOf(version)
+	if err != nil {
+		return err
+	}
+
+	dr := codec.NewDecodingReader(bytes.NewReader(data), uint64(len(data)))
+	if err := dr.Container(obj.sszFields(&spec.Spec, fork)...); err != nil {
+		return fmt.Errorf("failed to decode %s light client data: %w", version, err)
+	}
+	return nil
+}
+
+// lightClientHeaderSSZ decodes a LightClientHeader with the SSZ shape of a fork
+type lightClientHeaderSSZ struct {
+	header *LightClientHeader
+	fork   lightClientFork
+}
+
+func (h lightClientHeaderSSZ) Deserialize(dr *codec.DecodingReader) error {
+	h.header.Execution = h.fork.newExecutionHeader()
+	if h.header.Execution == nil {
+		h.header.ExecutionBranch = nil
+		return dr.FixedLenContainer(&h.header.Beacon)
+	}
+	execution, err := h.header.Execution.field()
+	if err != nil {
+		return err
+	}
+	return dr.Container(&h.header.Beacon, execution, merkleBranchSSZ{&h.header.ExecutionBranch, executionBranchDepth})
+}
+
+func (h lightClientHeaderSSZ) FixedLength() uint64 {
+	if h.fork.newExecutionHeader() == nil {
+		return h.header.Beacon.FixedLength()
+	}
+	return 0
+}
+
+// merkleBranchSSZ decodes a Merkle branch encoded as Vector[Bytes32, depth]
+type merkleBranchSSZ struct {
+	branch *[]common.Hash
+	depth  uint64
+}
+
+func (b merkleBranchSSZ) Deserialize(dr *codec.DecodingReader) error {
+	var roots []tree.Root
+	if err := tree.ReadRoots(dr, &roots, b.depth); err != nil {
+		return err
+	}
+	*b.branch = make([]common.Hash, len(roots))
+	for i, root := range roots {
+		(*b.branch)[i] = common.Hash(root)
+	}
+	return nil
+}
+
+func (b merkleBranchSSZ) FixedLength() uint64 {
+	return b.depth * 32
+}
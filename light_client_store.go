@@ -0,0 +1,619 @@
+package beaconclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	blsu "github.com/protolambda/bls12-381-util"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// Generalized indices of the light client proofs
+// Electra appended fields to the beacon state, which moved the state proofs one level down
+const (
+	finalizedRootGindex               = 105
+	currentSyncCommitteeGindex        = 54
+	nextSyncCommitteeGindex           = 55
+	finalizedRootGindexElectra        = 169
+	currentSyncCommitteeGindexElectra = 86
+	nextSyncCommitteeGindexElectra    = 87
+	executionPayloadGindex            = 25
+)
+
+// ErrInvalidMerkleBranch is returned when a Merkle branch of light client data does not verify
+var ErrInvalidMerkleBranch = errors.New("invalid Merkle branch")
+
+// LightClientStore tracks the beacon chain from a trusted block root by verifying light client updates
+// It implements the sync protocol of the consensus specs: every update must be signed by the
+// known sync committee, and the sync committees and finalized headers it carries must be proven
+// against the attested state root.
+//
+// A LightClientStore is safe for concurrent use.
+type LightClientStore struct {
+	spec    *Spec
+	genesis *GenesisData
+
+	mu                            sync.RWMutex
+	finalizedHeader               LightClientHeader
+	currentSyncCommittee          zrntcommon.SyncCommittee
+	nextSyncCommittee             zrntcommon.SyncCommittee
+	bestValidUpdate               *LightClientUpdate
+	optimisticHeader              LightClientHeader
+	previousMaxActiveParticipants uint64
+	currentMaxActiveParticipants  uint64
+}
+
+// NewLightClientStore initializes a light client store from the bootstrap of a trusted block root
+// This is equivalent to the Python spec function:
+//
+//	def initialize_light_client_store(trusted_block_root: Root,
+//	                                  bootstrap: LightClientBootstrap) -> LightClientStore:
+//	    assert is_valid_light_client_header(bootstrap.header)
+//	    assert hash_tree_root(bootstrap.header.beacon) == trusted_block_root
+//	    assert is_valid_normalized_merkle_branch(
+//	        leaf=hash_tree_root(bootstrap.current_sync_committee),
+//	        branch=bootstrap.current_sync_committee_branch,
+//	        gindex=current_sync_committee_gindex_at_slot(bootstrap.header.beacon.slot),
+//	        root=bootstrap.header.beacon.state_root,
+//	    )
+//	    return LightClientStore(finalized_header=bootstrap.header, ...)
+//
+// Parameters:
+//   - spec: the spec from GetSpec, providing the fork epochs and sync committee parameters
+//   - genesis: the genesis data from GetGenesis, providing the genesis validators root
+//   - trustedBlockRoot: the root of the bootstrap block, obtained from a trusted source
+//   - bootstrap: the bootstrap from GetLightClientBootstrap
+func NewLightClientStore(spec *Spec, genesis *GenesisData, trustedBlockRoot common.Hash, bootstrap *LightClientBootstrap) (*LightClientStore, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is required to verify light client data")
+	}
+	if _, err := genesis.validatorsRoot(); err != nil {
+		return nil, err
+	}
+	if bootstrap == nil {
+		return nil, fmt.Errorf("light client bootstrap is required")
+	}
+
+	if !isValidLightClientHeader(spec, &bootstrap.Header) {
+		return nil, fmt.Errorf("invalid bootstrap header: %w", ErrInvalidMerkleBranch)
+	}
+	if root := bootstrap.Header.Beacon.HashTreeRoot(); root != trustedBlockRoot {
+		return nil, fmt.Errorf("bootstrap header root %s does not match trusted block root %s", root, trustedBlockRoot)
+	}
+	gindex := uint64(currentSyncCommitteeGindex)
+	if isElectraLightClientSlot(spec, bootstrap.Header.Beacon.Slot) {
+		gindex = currentSyncCommitteeGindexElectra
+	}
+	leaf := syncCommitteeRoot(spec, &bootstrap.CurrentSyncCommittee)
+	if !isValidNormalizedMerkleBranch(leaf, bootstrap.CurrentSyncCommitteeBranch, gindex, bootstrap.Header.Beacon.StateRoot) {
+		return nil, fmt.Errorf("current sync committee: %w", ErrInvalidMerkleBranch)
+	}
+
+	return &LightClientStore{
+		spec:                 spec,
+		genesis:              genesis,
+		finalizedHeader:      bootstrap.Header,
+		currentSyncCommittee: bootstrap.CurrentSyncCommittee,
+		optimisticHeader:     bootstrap.Header,
+	}, nil
+}
+
+// NewLightClientStore creates a light client store from the bootstrap of a trusted block root
+// The spec, genesis data and bootstrap are fetched from the beacon node, the bootstrap is verified
+// against trustedBlockRoot, which must come from a trusted source such as a finalized checkpoint.
+func (c *Client) NewLightClientStore(ctx context.Context, trustedBlockRoot common.Hash) (*LightClientStore, error) {
+	spec, err := c.GetSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	genesis, err := c.GetGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bootstrap, err := c.GetLightClientBootstrap(ctx, trustedBlockRoot)
+	if err != nil {
+		return nil, err
+	}
+	return NewLightClientStore(spec, genesis, trustedBlockRoot, &bootstrap.Data)
+}
+
+// FinalizedHeader returns the latest finalized header
+func (s *LightClientStore) FinalizedHeader() LightClientHeader {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.finalizedHeader
+}
+
+// OptimisticHeader returns the latest header signed by a sufficient part of the sync committee
+func (s *LightClientStore) OptimisticHeader() LightClientHeader {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.optimisticHeader
+}
+
+// CurrentSyncCommittee returns the sync committee of the period of the finalized header
+func (s *LightClientStore) CurrentSyncCommittee() zrntcommon.SyncCommittee {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.currentSyncCommittee
+}
+
+// NextSyncCommittee returns the sync committee of the period after the finalized header, if known
+func (s *LightClientStore) NextSyncCommittee() (zrntcommon.SyncCommittee, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.nextSyncCommittee, s.isNextSyncCommitteeKnown()
+}
+
+// Period returns the sync committee period of the finalized header
+// Updates from this period on, see GetLightClientUpdates, advance the store.
+func (s *LightClientStore) Period() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ComputeSyncCommitteePeriodAtSlot(s.spec, s.finalizedHeader.Beacon.Slot)
+}
+
+// ProcessUpdate verifies a light client update and applies it to the store
+// This is equivalent to the Python spec function process_light_client_update
+//
+// The update is kept as best valid update for ProcessForceUpdate, advances the optimistic header
+// if enough sync committee members signed it, and advances the finalized header and sync
+// committees if at least two thirds of the sync committee signed it.
+// An invalid update returns an error and leaves the store unchanged.
+// currentSlot is the wall clock slot, see SlotClock.
+func (s *LightClientStore) ProcessUpdate(update *LightClientUpdate, currentSlot uint64) error {
+	if update == nil {
+		return fmt.Errorf("light client update is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.processUpdate(update, currentSlot)
+}
+
+// ProcessFinalityUpdate verifies a light client finality update and applies it to the store
+// This is equivalent to the Python spec function process_light_client_finality_update
+func (s *LightClientStore) ProcessFinalityUpdate(update *LightClientFinalityUpdate, currentSlot uint64) error {
+	if update == nil {
+		return fmt.Errorf("light client finality update is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.processUpdate(&LightClientUpdate{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}, currentSlot)
+}
+
+// ProcessOptimisticUpdate verifies a light client optimistic update and applies it to the store
+// This is equivalent to the Python spec function process_light_client_optimistic_update
+func (s *LightClientStore) ProcessOptimisticUpdate(update *LightClientOptimisticUpdate, currentSlot uint64) error {
+	if update == nil {
+		return fmt.Errorf("light client optimistic update is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.processUpdate(&LightClientUpdate{
+		AttestedHeader: update.AttestedHeader,
+		SyncAggregate:  update.SyncAggregate,
+		SignatureSlot:  update.SignatureSlot,
+	}, currentSlot)
+}
+
+// ProcessForceUpdate applies the best valid update if the finalized header did not advance for a sync committee period
+// This is equivalent to the Python spec function:
+//
+//	def process_light_client_store_force_update(store: LightClientStore, current_slot: Slot) -> None:
+//	    if (
+//	        current_slot > store.finalized_header.beacon.slot + UPDATE_TIMEOUT
+//	        and store.best_valid_update is not None
+//	    ):
+//	        # Forced best update when the update timeout has elapsed.
+//	        # Because the apply logic waits for `finalized_header.beacon.slot` to indicate sync committee finality,
+//	        # the `attested_header` may be treated as `finalized_header` in extended periods of non-finality
+//	        # to guarantee progression into later sync committee periods according to `is_better_update`.
+//	        if store.best_valid_update.finalized_header.beacon.slot <= store.finalized_header.beacon.slot:
+//	            store.best_valid_update.finalized_header = store.best_valid_update.attested_header
+//	        apply_light_client_update(store, store.best_valid_update)
+//	        store.best_valid_update = None
+//
+// Returns whether an update was applied
+func (s *LightClientStore) ProcessForceUpdate(currentSlot uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updateTimeout := uint64(s.spec.SLOTS_PER_EPOCH) * uint64(s.spec.EPOCHS_PER_SYNC_COMMITTEE_PERIOD)
+	if currentSlot <= s.finalizedHeader.Beacon.Slot+updateTimeout || s.bestValidUpdate == nil {
+		return false
+	}
+	if s.bestValidUpdate.FinalizedHeader.Beacon.Slot <= s.finalizedHeader.Beacon.Slot {
+		s.bestValidUpdate.FinalizedHeader = s.bestValidUpdate.AttestedHeader
+	}
+	s.applyUpdate(s.bestValidUpdate)
+	s.bestValidUpdate = nil
+	return true
+}
+
+func (s *LightClientStore) processUpdate(update *LightClientUpdate, currentSlot uint64) error {
+	if err := s.validateUpdate(update, currentSlot); err != nil {
+		return err
+	}
+
+	participants := syncCommitteeParticipants(update)
+	if s.bestValidUpdate == nil || s.isBetterUpdate(update, s.bestValidUpdate) {
+		best := *update
+		s.bestValidUpdate = &best
+	}
+	s.currentMaxActiveParticipants = max(s.currentMaxActiveParticipants, participants)
+
+	safetyThreshold := (s.previousMaxActiveParticipants + s.currentMaxActiveParticipants) / 2
+	if participants > safetyThreshold && update.AttestedHeader.Beacon.Slot > s.optimisticHeader.Beacon.Slot {
+		s.optimisticHeader = update.AttestedHeader
+	}
+
+	updateHasFinalizedNextSyncCommittee := !s.isNextSyncCommitteeKnown() &&
+		isSyncCommitteeUpdate(update) && isFinalityUpdate(update) &&
+		ComputeSyncCommitteePeriodAtSlot(s.spec, update.FinalizedHeader.Beacon.Slot) ==
+			ComputeSyncCommitteePeriodAtSlot(s.spec, update.AttestedHeader.Beacon.Slot)
+	if participants*3 >= uint64(s.spec.SYNC_COMMITTEE_SIZE)*2 &&
+		(update.FinalizedHeader.Beacon.Slot > s.finalizedHeader.Beacon.Slot || updateHasFinalizedNextSyncCommittee) {
+		s.applyUpdate(update)
+		s.bestValidUpdate = nil
+	}
+	return nil
+}
+
+// validateUpdate is equivalent to the Python spec function validate_light_client_update
+func (s *LightClientStore) validateUpdate(update *LightClientUpdate, currentSlot uint64) error {
+	spec := s.spec
+	if uint64(len(update.SyncAggregate.SyncCommitteeBits))*8 != uint64(spec.SYNC_COMMITTEE_SIZE) {
+		return fmt.Errorf("invalid sync committee bits length %d", len(update.SyncAggregate.SyncCommitteeBits))
+	}
+	if participants := syncCommitteeParticipants(update); participants < uint64(spec.MIN_SYNC_COMMITTEE_PARTICIPANTS) {
+		return fmt.Errorf("not enough sync committee participants: %d", participants)
+	}
+	if !isValidLightClientHeader(spec, &update.AttestedHeader) {
+		return fmt.Errorf("invalid attested header: %w", ErrInvalidMerkleBranch)
+	}
+
+	attestedSlot := update.AttestedHeader.Beacon.Slot
+	finalizedSlot := update.FinalizedHeader.Beacon.Slot
+	if currentSlot < update.SignatureSlot || update.SignatureSlot <= attestedSlot || attestedSlot < finalizedSlot {
+		return fmt.Errorf("invalid update slots: current %d, signature %d, attested %d, finalized %d",
+			currentSlot, update.SignatureSlot, attestedSlot, finalizedSlot)
+	}
+
+	storePeriod := ComputeSyncCommitteePeriodAtSlot(spec, s.finalizedHeader.Beacon.Slot)
+	signaturePeriod := ComputeSyncCommitteePeriodAtSlot(spec, update.SignatureSlot)
+	if s.isNextSyncCommitteeKnown() {
+		if signaturePeriod != storePeriod && signaturePeriod != storePeriod+1 {
+			return fmt.Errorf("update signature period %d is not %d or %d", signaturePeriod, storePeriod, storePeriod+1)
+		}
+	} else if signaturePeriod != storePeriod {
+		return fmt.Errorf("update signature period %d is not %d", signaturePeriod, storePeriod)
+	}
+
+	attestedPeriod := ComputeSyncCommitteePeriodAtSlot(spec, attestedSlot)
+	updateHasNextSyncCommittee := !s.isNextSyncCommitteeKnown() && isSyncCommitteeUpdate(update) && attestedPeriod == storePeriod
+	if attestedSlot <= s.finalizedHeader.Beacon.Slot && !updateHasNextSyncCommittee {
+		return fmt.Errorf("update attested slot %d is not newer than finalized slot %d", attestedSlot, s.finalizedHeader.Beacon.Slot)
+	}
+
+	electra := isElectraLightClientSlot(spec, attestedSlot)
+	if !isFinalityUpdate(update) {
+		if !isEmptyLightClientHeader(&update.FinalizedHeader) {
+			return fmt.Errorf("finalized header without finality branch")
+		}
+	} else {
+		var finalizedRoot common.Hash
+		if finalizedSlot == GENESIS_SLOT {
+			if !isEmptyLightClientHeader(&update.FinalizedHeader) {
+				return fmt.Errorf("finalized header at genesis slot must be empty")
+			}
+		} else {
+			if !isValidLightClientHeader(spec, &update.FinalizedHeader) {
+				return fmt.Errorf("invalid finalized header: %w", ErrInvalidMerkleBranch)
+			}
+			finalizedRoot = update.FinalizedHeader.Beacon.HashTreeRoot()
+		}
+		gindex := uint64(finalizedRootGindex)
+		if electra {
+			gindex = finalizedRootGindexElectra
+		}
+		if !isValidNormalizedMerkleBranch(finalizedRoot, update.FinalityBranch, gindex, update.AttestedHeader.Beacon.StateRoot) {
+			return fmt.Errorf("finality: %w", ErrInvalidMerkleBranch)
+		}
+	}
+
+	if !isSyncCommitteeUpdate(update) {
+		if !isEmptySyncCommittee(&update.NextSyncCommittee) {
+			return fmt.Errorf("next sync committee without next sync committee branch")
+		}
+	} else {
+		if attestedPeriod == storePeriod && s.isNextSyncCommitteeKnown() &&
+			syncCommitteeRoot(spec, &update.NextSyncCommittee) != syncCommitteeRoot(spec, &s.nextSyncCommittee) {
+			return fmt.Errorf("next sync committee does not match the known next sync committee")
+		}
+		gindex := uint64(nextSyncCommitteeGindex)
+		if electra {
+			gindex = nextSyncCommitteeGindexElectra
+		}
+		leaf := syncCommitteeRoot(spec, &update.NextSyncCommittee)
+		if !isValidNormalizedMerkleBranch(leaf, update.NextSyncCommitteeBranch, gindex, update.AttestedHeader.Beacon.StateRoot) {
+			return fmt.Errorf("next sync committee: %w", ErrInvalidMerkleBranch)
+		}
+	}
+
+	syncCommittee := &s.currentSyncCommittee
+	if signaturePeriod != storePeriod {
+		syncCommittee = &s.nextSyncCommittee
+	}
+	return s.verifySyncAggregate(update, syncCommittee)
+}
+
+// verifySyncAggregate verifies the sync committee signature over the attested header
+// The domain is the one of the fork at the slot before the signature slot.
+func (s *LightClientStore) verifySyncAggregate(update *LightClientUpdate, syncCommittee *zrntcommon.SyncCommittee) error {
+	if uint64(len(syncCommittee.Pubkeys)) != uint64(s.spec.SYNC_COMMITTEE_SIZE) {
+		return fmt.Errorf("invalid sync committee size %d", len(syncCommittee.Pubkeys))
+	}
+
+	pubkeys := make([]*blsu.Pubkey, 0, syncCommitteeParticipants(update))
+	for i := range syncCommittee.Pubkeys {
+		if update.SyncAggregate.SyncCommitteeBits[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		pubkey, err := syncCommittee.Pubkeys[i].Pubkey()
+		if err != nil {
+			return fmt.Errorf("invalid pubkey of sync committee member %d: %w", i, err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	signature, err := update.SyncAggregate.SyncCommitteeSignature.Signature()
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	forkVersionSlot := max(update.SignatureSlot, 1) - 1
+	domain, err := ComputeDomain(s.spec, s.genesis, zrntcommon.DOMAIN_SYNC_COMMITTEE, ComputeEpochAtSlot(s.spec, forkVersionSlot))
+	if err != nil {
+		return err
+	}
+	signingRoot := ComputeSigningRoot(update.AttestedHeader.Beacon.HashTreeRoot(), domain)
+	if !blsu.FastAggregateVerify(pubkeys, signingRoot[:], signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// applyUpdate is equivalent to the Python spec function apply_light_client_update
+func (s *LightClientStore) applyUpdate(update *LightClientUpdate) {
+	storePeriod := ComputeSyncCommitteePeriodAtSlot(s.spec, s.finalizedHeader.Beacon.Slot)
+	finalizedPeriod := ComputeSyncCommitteePeriodAtSlot(s.spec, update.FinalizedHeader.Beacon.Slot)
+	if !s.isNextSyncCommitteeKnown() {
+		// validateUpdate only lets updates of the store period through in this case
+		if finalizedPeriod == storePeriod {
+			s.nextSyncCommittee = update.NextSyncCommittee
+		}
+	} else if finalizedPeriod == storePeriod+1 {
+		s.currentSyncCommittee = s.nextSyncCommittee
+		s.nextSyncCommittee = update.NextSyncCommittee
+		s.previousMaxActiveParticipants = s.currentMaxActiveParticipants
+		s.currentMaxActiveParticipants = 0
+	}
+	if update.FinalizedHeader.Beacon.Slot > s.finalizedHeader.Beacon.Slot {
+		s.finalizedHeader = update.FinalizedHeader
+		if s.finalizedHeader.Beacon.Slot > s.optimisticHeader.Beacon.Slot {
+			s.optimisticHeader = s.finalizedHeader
+		}
+	}
+}
+
+// isBetterUpdate is equivalent to the Python spec function is_better_update
+func (s *LightClientStore) isBetterUpdate(newUpdate, oldUpdate *LightClientUpdate) bool {
+	spec := s.spec
+	maxActiveParticipants := uint64(spec.SYNC_COMMITTEE_SIZE)
+	newActive := syncCommitteeParticipants(newUpdate)
+	oldActive := syncCommitteeParticipants(oldUpdate)
+	newHasSupermajority := newActive*3 >= maxActiveParticipants*2
+	oldHasSupermajority := oldActive*3 >= maxActiveParticipants*2
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newActive != oldActive {
+		return newActive > oldActive
+	}
+
+	// compare presence of relevant sync committee
+	newHasRelevantSyncCommittee := isSyncCommitteeUpdate(newUpdate) &&
+		ComputeSyncCommitteePeriodAtSlot(spec, newUpdate.AttestedHeader.Beacon.Slot) ==
+			ComputeSyncCommitteePeriodAtSlot(spec, newUpdate.SignatureSlot)
+	oldHasRelevantSyncCommittee := isSyncCommitteeUpdate(oldUpdate) &&
+		ComputeSyncCommitteePeriodAtSlot(spec, oldUpdate.AttestedHeader.Beacon.Slot) ==
+			ComputeSyncCommitteePeriodAtSlot(spec, oldUpdate.SignatureSlot)
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	// compare indication of any finality
+	newHasFinality := isFinalityUpdate(newUpdate)
+	oldHasFinality := isFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	// compare sync committee finality
+	if newHasFinality {
+		newHasSyncCommitteeFinality := ComputeSyncCommitteePeriodAtSlot(spec, newUpdate.FinalizedHeader.Beacon.Slot) ==
+			ComputeSyncCommitteePeriodAtSlot(spec, newUpdate.AttestedHeader.Beacon.Slot)
+		oldHasSyncCommitteeFinality := ComputeSyncCommitteePeriodAtSlot(spec, oldUpdate.FinalizedHeader.Beacon.Slot) ==
+			ComputeSyncCommitteePeriodAtSlot(spec, oldUpdate.AttestedHeader.Beacon.Slot)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	// tiebreaker 1: sync committee participation beyond supermajority
+	if newActive != oldActive {
+		return newActive > oldActive
+	}
+	// tiebreaker 2: prefer older data (fewer changes to best)
+	if newUpdate.AttestedHeader.Beacon.Slot != oldUpdate.AttestedHeader.Beacon.Slot {
+		return newUpdate.AttestedHeader.Beacon.Slot < oldUpdate.AttestedHeader.Beacon.Slot
+	}
+	// tiebreaker 3: prefer updates with earlier signature slots
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
+func (s *LightClientStore) isNextSyncCommitteeKnown() bool {
+	return !isEmptySyncCommittee(&s.nextSyncCommittee)
+}
+
+// isElectraLightClientSlot reports whether the state proofs of a header at slot use the Electra gindices
+func isElectraLightClientSlot(spec *Spec, slot uint64) bool {
+	return ComputeEpochAtSlot(spec, slot) >= scheduledForkEpoch(spec, ConsensusVersionElectra)
+}
+
+// isValidLightClientHeader is equivalent to the Python spec function is_valid_light_client_header
+// Headers before Capella must not carry execution data, later headers must prove it against the body root.
+func isValidLightClientHeader(spec *Spec, header *LightClientHeader) bool {
+	epoch := ComputeEpochAtSlot(spec, header.Beacon.Slot)
+	if epoch < scheduledForkEpoch(spec, ConsensusVersionCapella) {
+		return (header.Execution == nil || isEmptyExecutionPayloadHeader(header.Execution)) &&
+			isZeroBranch(header.ExecutionBranch)
+	}
+	if header.Execution == nil || len(header.ExecutionBranch) != executionBranchDepth {
+		return false
+	}
+
+	root, ok := lightClientExecutionRoot(spec, header.Execution, epoch)
+	if !ok {
+		return false
+	}
+	return IsValidMerkleBranch(root, header.ExecutionBranch, executionBranchDepth,
+		executionPayloadGindex%(1<<executionBranchDepth), header.Beacon.BodyRoot)
+}
+
+// lightClientExecutionRoot is equivalent to the Python spec function get_lc_execution_root
+// A Deneb header of a Capella block is hashed as the Capella header it was upgraded from.
+func lightClientExecutionRoot(spec *Spec, execution *ExecutionPayloadHeader, epoch uint64) (common.Hash, bool) {
+	if epoch >= scheduledForkEpoch(spec, ConsensusVersionDeneb) {
+		if execution.Deneb == nil {
+			return common.Hash{}, false
+		}
+		return execution.HashTreeRoot(), true
+	}
+	if execution.Capella != nil {
+		return execution.HashTreeRoot(), true
+	}
+	if execution.Deneb == nil || execution.Deneb.BlobGasUsed != 0 || execution.Deneb.ExcessBlobGas != 0 {
+		return common.Hash{}, false
+	}
+
+	header := execution.Deneb
+	capellaHeader := capella.ExecutionPayloadHeader{
+		ParentHash:       header.ParentHash,
+		FeeRecipient:     header.FeeRecipient,
+		StateRoot:        header.StateRoot,
+		ReceiptsRoot:     header.ReceiptsRoot,
+		LogsBloom:        header.LogsBloom,
+		PrevRandao:       header.PrevRandao,
+		BlockNumber:      header.BlockNumber,
+		GasLimit:         header.GasLimit,
+		GasUsed:          header.GasUsed,
+		Timestamp:        header.Timestamp,
+		ExtraData:        header.ExtraData,
+		BaseFeePerGas:    header.BaseFeePerGas,
+		BlockHash:        header.BlockHash,
+		TransactionsRoot: header.TransactionsRoot,
+		WithdrawalsRoot:  header.WithdrawalsRoot,
+	}
+	return common.Hash(capellaHeader.HashTreeRoot(tree.GetHashFn())), true
+}
+
+// isValidNormalizedMerkleBranch is equivalent to the Python spec function is_valid_normalized_merkle_branch
+// Branches longer than the depth of gindex must be padded with zero hashes at the start.
+func isValidNormalizedMerkleBranch(leaf common.Hash, branch []common.Hash, gindex uint64, root common.Hash) bool {
+	depth := uint64(bits.Len64(gindex) - 1)
+	index := gindex % (1 << depth)
+	if uint64(len(branch)) < depth {
+		return false
+	}
+
+	extra := uint64(len(branch)) - depth
+	if !isZeroBranch(branch[:extra]) {
+		return false
+	}
+	return IsValidMerkleBranch(leaf, branch[extra:], depth, index, root)
+}
+
+// isSyncCommitteeUpdate is equivalent to the Python spec function is_sync_committee_update
+func isSyncCommitteeUpdate(update *LightClientUpdate) bool {
+	return !isZeroBranch(update.NextSyncCommitteeBranch)
+}
+
+// isFinalityUpdate is equivalent to the Python spec function is_finality_update
+func isFinalityUpdate(update *LightClientUpdate) bool {
+	return !isZeroBranch(update.FinalityBranch)
+}
+
+func syncCommitteeParticipants(update *LightClientUpdate) uint64 {
+	var participants int
+	for _, b := range update.SyncAggregate.SyncCommitteeBits {
+		participants += bits.OnesCount8(b)
+	}
+	return uint64(participants)
+}
+
+func syncCommitteeRoot(spec *Spec, syncCommittee *zrntcommon.SyncCommittee) common.Hash {
+	return common.Hash(syncCommittee.HashTreeRoot(&spec.Spec, tree.GetHashFn()))
+}
+
+func isZeroBranch(branch []common.Hash) bool {
+	for _, node := range branch {
+		if node != (common.Hash{}) {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmptySyncCommittee(syncCommittee *zrntcommon.SyncCommittee) bool {
+	if syncCommittee.AggregatePubkey != (zrntcommon.BLSPubkey{}) {
+		return false
+	}
+	for _, pubkey := range syncCommittee.Pubkeys {
+		if pubkey != (zrntcommon.BLSPubkey{}) {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmptyExecutionPayloadHeader(execution *ExecutionPayloadHeader) bool {
+	var empty ExecutionPayloadHeader
+	switch {
+	case execution.Capella != nil:
+		empty.Capella = new(capella.ExecutionPayloadHeader)
+	case execution.Deneb != nil:
+		empty.Deneb = new(deneb.ExecutionPayloadHeader)
+	default:
+		return true
+	}
+	return execution.HashTreeRoot() == empty.HashTreeRoot()
+}
+
+func isEmptyLightClientHeader(header *LightClientHeader) bool {
+	return header.Beacon == (BeaconBlockHeader{}) &&
+		(header.Execution == nil || isEmptyExecutionPayloadHeader(header.Execution)) &&
+		isZeroBranch(header.ExecutionBranch)
+}
@@ -0,0 +1,587 @@
+package beaconclient
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blsu "github.com/protolambda/bls12-381-util"
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/view"
+)
+
+// newMinimalLightClientSpec returns the minimal preset with all forks up to Deneb at genesis
+// and Electra at the start of sync committee period 2 (8 slots per epoch, 8 epochs per period)
+func newMinimalLightClientSpec() *Spec {
+	spec := &Spec{Spec: *configs.Minimal}
+	spec.ALTAIR_FORK_EPOCH = 0
+	spec.BELLATRIX_FORK_EPOCH = 0
+	spec.CAPELLA_FORK_EPOCH = 0
+	spec.DENEB_FORK_EPOCH = 0
+	spec.ELECTRA_FORK_EPOCH = 16
+	spec.FULU_FORK_EPOCH = math.MaxUint64
+	return spec
+}
+
+// testMerkleTree is a sparse Merkle tree of which only the leaves at the given gindices are known
+// All other subtrees are filled with arbitrary nodes
+type testMerkleTree map[uint64]common.Hash
+
+func (t testMerkleTree) node(gindex uint64) common.Hash {
+	if leaf, ok := t[gindex]; ok {
+		return leaf
+	}
+	if !t.hasLeafBelow(gindex) {
+		var filler [8]byte
+		binary.LittleEndian.PutUint64(filler[:], gindex)
+		return sha256.Sum256(filler[:])
+	}
+	left, right := t.node(2*gindex), t.node(2*gindex+1)
+	return sha256.Sum256(append(left[:], right[:]...))
+}
+
+func (t testMerkleTree) hasLeafBelow(gindex uint64) bool {
+	for leaf := range t {
+		for ; leaf > gindex; leaf >>= 1 {
+		}
+		if leaf == gindex {
+			return true
+		}
+	}
+	return false
+}
+
+func (t testMerkleTree) root() common.Hash {
+	return t.node(1)
+}
+
+func (t testMerkleTree) branch(gindex uint64) []common.Hash {
+	var branch []common.Hash
+	for ; gindex > 1; gindex >>= 1 {
+		branch = append(branch, t.node(gindex^1))
+	}
+	return branch
+}
+
+// testSyncCommittee is a sync committee of which the secret keys are known
+type testSyncCommittee struct {
+	keys      []*blsu.SecretKey
+	committee zrntcommon.SyncCommittee
+}
+
+func newTestSyncCommitteeKeys(t *testing.T, spec *Spec, seed byte) *testSyncCommittee {
+	t.Helper()
+	c := &testSyncCommittee{}
+	pubkeys := make([]*blsu.Pubkey, spec.SYNC_COMMITTEE_SIZE)
+	for i := range pubkeys {
+		var secret [32]byte
+		secret[30], secret[31] = seed, byte(i+1)
+		var sk blsu.SecretKey
+		if err := sk.Deserialize(&secret); err != nil {
+			t.Fatalf("failed to create secret key: %v", err)
+		}
+		pub, err := blsu.SkToPk(&sk)
+		if err != nil {
+			t.Fatalf("failed to derive pubkey: %v", err)
+		}
+		c.keys = append(c.keys, &sk)
+		c.committee.Pubkeys = append(c.committee.Pubkeys, pub.Serialize())
+		pubkeys[i] = pub
+	}
+	aggregate, err := blsu.AggregatePubkeys(pubkeys)
+	if err != nil {
+		t.Fatalf("failed to aggregate pubkeys: %v", err)
+	}
+	c.committee.AggregatePubkey = aggregate.Serialize()
+	return c
+}
+
+// sign returns the sync aggregate of the first participants members over the header
+func (c *testSyncCommittee) sign(t *testing.T, spec *Spec, header *BeaconBlockHeader, signatureSlot uint64, participants int) altair.SyncAggregate {
+	t.Helper()
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+	domain, err := ComputeDomain(spec, genesis, zrntcommon.DOMAIN_SYNC_COMMITTEE, ComputeEpochAtSlot(spec, signatureSlot-1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signingRoot := ComputeSigningRoot(header.HashTreeRoot(), domain)
+
+	bits := make(altair.SyncCommitteeBits, spec.SYNC_COMMITTEE_SIZE/8)
+	signatures := make([]*blsu.Signature, participants)
+	for i := range participants {
+		bits[i/8] |= 1 << (i % 8)
+		signatures[i] = blsu.Sign(c.keys[i], signingRoot[:])
+	}
+	aggregate, err := blsu.Aggregate(signatures)
+	if err != nil {
+		t.Fatalf("failed to aggregate signatures: %v", err)
+	}
+	return altair.SyncAggregate{SyncCommitteeBits: bits, SyncCommitteeSignature: aggregate.Serialize()}
+}
+
+// newTestStoreHeader returns a Deneb light client header with a valid execution branch
+func newTestStoreHeader(slot uint64, stateRoot common.Hash) LightClientHeader {
+	execution := &ExecutionPayloadHeader{Deneb: &deneb.ExecutionPayloadHeader{
+		BlockNumber: view.Uint64View(slot),
+		BlockHash:   zrntcommon.Hash32{byte(slot)},
+	}}
+	body := testMerkleTree{executionPayloadGindex: execution.HashTreeRoot()}
+	return LightClientHeader{
+		Beacon: BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: slot % 7,
+			ParentRoot:    common.Hash{byte(slot - 1)},
+			StateRoot:     stateRoot,
+			BodyRoot:      body.root(),
+		},
+		Execution:       execution,
+		ExecutionBranch: body.branch(executionPayloadGindex),
+	}
+}
+
+func newTestBootstrap(spec *Spec, slot uint64, committee *testSyncCommittee) (*LightClientBootstrap, common.Hash) {
+	gindex := uint64(currentSyncCommitteeGindex)
+	if isElectraLightClientSlot(spec, slot) {
+		gindex = currentSyncCommitteeGindexElectra
+	}
+	state := testMerkleTree{gindex: syncCommitteeRoot(spec, &committee.committee)}
+	bootstrap := &LightClientBootstrap{
+		Header:                     newTestStoreHeader(slot, state.root()),
+		CurrentSyncCommittee:       committee.committee,
+		CurrentSyncCommitteeBranch: state.branch(gindex),
+	}
+	return bootstrap, bootstrap.Header.Beacon.HashTreeRoot()
+}
+
+// newTestStoreUpdate returns an update attesting a state that finalized finalizedSlot and
+// contains the next sync committee, if not nil, signed by participants members of signers
+func newTestStoreUpdate(t *testing.T, spec *Spec, attestedSlot, finalizedSlot uint64, next, signers *testSyncCommittee, participants int) *LightClientUpdate {
+	t.Helper()
+	finalityGindex, nextGindex := uint64(finalizedRootGindex), uint64(nextSyncCommitteeGindex)
+	if isElectraLightClientSlot(spec, attestedSlot) {
+		finalityGindex, nextGindex = finalizedRootGindexElectra, nextSyncCommitteeGindexElectra
+	}
+
+	update := &LightClientUpdate{
+		FinalizedHeader: newTestStoreHeader(finalizedSlot, common.Hash{byte(finalizedSlot)}),
+		SignatureSlot:   attestedSlot + 1,
+	}
+	state := testMerkleTree{finalityGindex: update.FinalizedHeader.Beacon.HashTreeRoot()}
+	if next != nil {
+		update.NextSyncCommittee = next.committee
+		state[nextGindex] = syncCommitteeRoot(spec, &next.committee)
+	}
+
+	update.AttestedHeader = newTestStoreHeader(attestedSlot, state.root())
+	update.FinalityBranch = state.branch(finalityGindex)
+	if next != nil {
+		update.NextSyncCommitteeBranch = state.branch(nextGindex)
+	}
+	update.SyncAggregate = signers.sign(t, spec, &update.AttestedHeader.Beacon, update.SignatureSlot, participants)
+	return update
+}
+
+func newTestLightClientStore(t *testing.T, spec *Spec, committee *testSyncCommittee) *LightClientStore {
+	t.Helper()
+	bootstrap, root := newTestBootstrap(spec, 8, committee)
+	store, err := NewLightClientStore(spec, &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}, root, bootstrap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return store
+}
+
+func TestLightClientStore_Sync(t *testing.T) {
+	spec := newMinimalLightClientSpec()
+	committees := []*testSyncCommittee{
+		newTestSyncCommitteeKeys(t, spec, 1),
+		newTestSyncCommitteeKeys(t, spec, 2),
+		newTestSyncCommitteeKeys(t, spec, 3),
+		newTestSyncCommitteeKeys(t, spec, 4),
+	}
+	store := newTestLightClientStore(t, spec, committees[0])
+	if _, known := store.NextSyncCommittee(); known {
+		t.Fatal("expected unknown next sync committee after bootstrap")
+	}
+
+	// the updates of period 0, 1 and 2 (Electra) are passed through JSON like the API data
+	updates := []*LightClientUpdate{
+		newTestStoreUpdate(t, spec, 40, 24, committees[1], committees[0], 32),
+		newTestStoreUpdate(t, spec, 72, 66, committees[2], committees[1], 32),
+		newTestStoreUpdate(t, spec, 136, 130, committees[3], committees[2], 32),
+	}
+	for i, update := range updates {
+		data, err := json.Marshal(update)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var decoded LightClientUpdate
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := store.ProcessUpdate(&decoded, update.SignatureSlot+1); err != nil {
+			t.Fatalf("update %d: unexpected error: %v", i, err)
+		}
+		if store.FinalizedHeader().Beacon.Slot != update.FinalizedHeader.Beacon.Slot {
+			t.Errorf("update %d: expected finalized slot %d, got %d", i, update.FinalizedHeader.Beacon.Slot, store.FinalizedHeader().Beacon.Slot)
+		}
+		if store.Period() != uint64(i) {
+			t.Errorf("update %d: expected period %d, got %d", i, i, store.Period())
+		}
+	}
+
+	current := store.CurrentSyncCommittee()
+	if current.AggregatePubkey != committees[2].committee.AggregatePubkey {
+		t.Error("expected the sync committee of period 2 as current sync committee")
+	}
+	next, known := store.NextSyncCommittee()
+	if !known || next.AggregatePubkey != committees[3].committee.AggregatePubkey {
+		t.Error("expected the sync committee of period 3 as next sync committee")
+	}
+	// full participation is not above the safety threshold of two fully participating periods,
+	// so the optimistic header only advanced with the finalized header
+	if store.OptimisticHeader().Beacon.Slot != 130 {
+		t.Errorf("expected optimistic slot 130, got %d", store.OptimisticHeader().Beacon.Slot)
+	}
+}
+
+func TestLightClientStore_InvalidUpdates(t *testing.T) {
+	spec := newMinimalLightClientSpec()
+	committee := newTestSyncCommitteeKeys(t, spec, 1)
+	nextCommittee := newTestSyncCommitteeKeys(t, spec, 2)
+
+	tests := []struct {
+		name    string
+		modify  func(update *LightClientUpdate)
+		signers *testSyncCommittee
+		wantErr error
+	}{
+		{
+			name:    "unknown signers",
+			signers: nextCommittee,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "forged attested header",
+			modify:  func(update *LightClientUpdate) { update.AttestedHeader.Beacon.ProposerIndex++ },
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "invalid finality branch",
+			modify:  func(update *LightClientUpdate) { update.FinalityBranch[0][0] ^= 0xff },
+			wantErr: ErrInvalidMerkleBranch,
+		},
+		{
+			name:    "invalid next sync committee",
+			modify:  func(update *LightClientUpdate) { update.NextSyncCommittee.AggregatePubkey[0] ^= 0xff },
+			wantErr: ErrInvalidMerkleBranch,
+		},
+		{
+			name:    "invalid execution branch",
+			modify:  func(update *LightClientUpdate) { update.FinalizedHeader.ExecutionBranch[0][0] ^= 0xff },
+			wantErr: ErrInvalidMerkleBranch,
+		},
+		{
+			name:   "signature slot in the future",
+			modify: func(update *LightClientUpdate) { update.SignatureSlot = 100 },
+		},
+		{
+			name:   "signature of a later period",
+			modify: func(update *LightClientUpdate) { update.SignatureSlot = 65 },
+		},
+		{
+			name:   "no participants",
+			modify: func(update *LightClientUpdate) { clear(update.SyncAggregate.SyncCommitteeBits) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestLightClientStore(t, spec, committee)
+			signers := committee
+			if tt.signers != nil {
+				signers = tt.signers
+			}
+			update := newTestStoreUpdate(t, spec, 40, 24, nextCommittee, signers, 32)
+			if tt.modify != nil {
+				tt.modify(update)
+			}
+
+			err := store.ProcessUpdate(update, 80)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if store.FinalizedHeader().Beacon.Slot != 8 || store.OptimisticHeader().Beacon.Slot != 8 {
+				t.Error("expected the store to be unchanged")
+			}
+		})
+	}
+}
+
+func TestLightClientStore_NilUpdates(t *testing.T) {
+	spec := newMinimalLightClientSpec()
+	store := newTestLightClientStore(t, spec, newTestSyncCommitteeKeys(t, spec, 1))
+
+	if err := store.ProcessUpdate(nil, 80); err == nil {
+		t.Error("expected error for a nil update")
+	}
+	if err := store.ProcessFinalityUpdate(nil, 80); err == nil {
+		t.Error("expected error for a nil finality update")
+	}
+	if err := store.ProcessOptimisticUpdate(nil, 80); err == nil {
+		t.Error("expected error for a nil optimistic update")
+	}
+}
+
+func TestLightClientStore_FinalityAndOptimisticUpdates(t *testing.T) {
+	spec := newMinimalLightClientSpec()
+	committee := newTestSyncCommitteeKeys(t, spec, 1)
+	store := newTestLightClientStore(t, spec, committee)
+
+	// 20 of 32 participants are above the safety threshold, but below the 2/3 supermajority
+	update := newTestStoreUpdate(t, spec, 30, 16, nil, committee, 20)
+	if err := store.ProcessOptimisticUpdate(&LightClientOptimisticUpdate{
+		AttestedHeader: update.AttestedHeader,
+		SyncAggregate:  update.SyncAggregate,
+		SignatureSlot:  update.SignatureSlot,
+	}, 31); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.OptimisticHeader().Beacon.Slot != 30 {
+		t.Errorf("expected optimistic slot 30, got %d", store.OptimisticHeader().Beacon.Slot)
+	}
+	if store.FinalizedHeader().Beacon.Slot != 8 {
+		t.Errorf("expected finalized slot 8, got %d", store.FinalizedHeader().Beacon.Slot)
+	}
+
+	update = newTestStoreUpdate(t, spec, 40, 24, nil, committee, 24)
+	if err := store.ProcessFinalityUpdate(&LightClientFinalityUpdate{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}, 41); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.FinalizedHeader().Beacon.Slot != 24 {
+		t.Errorf("expected finalized slot 24, got %d", store.FinalizedHeader().Beacon.Slot)
+	}
+	if store.OptimisticHeader().Beacon.Slot != 40 {
+		t.Errorf("expected optimistic slot 40, got %d", store.OptimisticHeader().Beacon.Slot)
+	}
+	if _, known := store.NextSyncCommittee(); known {
+		t.Error("expected finality updates not to provide the next sync committee")
+	}
+}
+
+func TestLightClientStore_ForceUpdate(t *testing.T) {
+	spec := newMinimalLightClientSpec()
+	committee := newTestSyncCommitteeKeys(t, spec, 1)
+	nextCommittee := newTestSyncCommitteeKeys(t, spec, 2)
+	store := newTestLightClientStore(t, spec, committee)
+
+	// without supermajority the update is only kept as best valid update
+	update := newTestStoreUpdate(t, spec, 40, 24, nextCommittee, committee, 16)
+	if err := store.ProcessUpdate(update, 41); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.FinalizedHeader().Beacon.Slot != 8 {
+		t.Fatalf("expected finalized slot 8, got %d", store.FinalizedHeader().Beacon.Slot)
+	}
+
+	if store.ProcessForceUpdate(8 + 64) {
+		t.Fatal("expected no force update before the update timeout")
+	}
+	if !store.ProcessForceUpdate(8 + 65) {
+		t.Fatal("expected a force update after the update timeout")
+	}
+	if store.FinalizedHeader().Beacon.Slot != 24 {
+		t.Errorf("expected finalized slot 24, got %d", store.FinalizedHeader().Beacon.Slot)
+	}
+	if next, known := store.NextSyncCommittee(); !known || next.AggregatePubkey != nextCommittee.committee.AggregatePubkey {
+		t.Error("expected the next sync committee of the update")
+	}
+	if store.ProcessForceUpdate(8 + 65) {
+		t.Error("expected the best valid update to be consumed")
+	}
+}
+
+func TestNewLightClientStore_Invalid(t *testing.T) {
+	spec := newMinimalLightClientSpec()
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+	committee := newTestSyncCommitteeKeys(t, spec, 1)
+	bootstrap, root := newTestBootstrap(spec, 8, committee)
+
+	if _, err := NewLightClientStore(nil, genesis, root, bootstrap); err == nil {
+		t.Error("expected error for a nil spec")
+	}
+	if _, err := NewLightClientStore(spec, nil, root, bootstrap); err == nil {
+		t.Error("expected error for nil genesis data")
+	}
+	if _, err := NewLightClientStore(spec, genesis, root, nil); err == nil {
+		t.Error("expected error for a nil bootstrap")
+	}
+	if _, err := NewLightClientStore(spec, genesis, common.Hash{0x01}, bootstrap); err == nil {
+		t.Error("expected error for another trusted block root")
+	}
+
+	forged := *bootstrap
+	forged.CurrentSyncCommittee = newTestSyncCommitteeKeys(t, spec, 2).committee
+	if _, err := NewLightClientStore(spec, genesis, root, &forged); !errors.Is(err, ErrInvalidMerkleBranch) {
+		t.Errorf("expected ErrInvalidMerkleBranch, got %v", err)
+	}
+
+	// Electra moved the current sync committee one level down
+	spec.ELECTRA_FORK_EPOCH = 0
+	if _, err := NewLightClientStore(spec, genesis, root, bootstrap); !errors.Is(err, ErrInvalidMerkleBranch) {
+		t.Errorf("expected ErrInvalidMerkleBranch for the electra gindex, got %v", err)
+	}
+}
+
+func TestIsValidLightClientHeader(t *testing.T) {
+	spec := newMinimalLightClientSpec()
+	header := newTestStoreHeader(8, common.Hash{0x01})
+	if !isValidLightClientHeader(spec, &header) {
+		t.Error("expected a valid deneb header")
+	}
+
+	// a deneb header before capella must not carry execution data
+	spec.CAPELLA_FORK_EPOCH, spec.DENEB_FORK_EPOCH = 2, 2
+	if isValidLightClientHeader(spec, &header) {
+		t.Error("expected execution data before capella to be invalid")
+	}
+	altairHeader := LightClientHeader{Beacon: header.Beacon}
+	if !isValidLightClientHeader(spec, &altairHeader) {
+		t.Error("expected a valid altair header")
+	}
+
+	// a capella header after deneb is invalid
+	spec.CAPELLA_FORK_EPOCH, spec.DENEB_FORK_EPOCH = 0, 0
+	capellaHeader := newTestLightClientHeader(t, ConsensusVersionCapella, 8)
+	if isValidLightClientHeader(spec, &capellaHeader) {
+		t.Error("expected a capella header after deneb to be invalid")
+	}
+}
+
+// newLightClientStoreTestdataSpec returns the spec of the light client store testdata, the minimal preset
+// with Altair at genesis, Capella and Deneb at period 1 and Electra at period 2
+func newLightClientStoreTestdataSpec() *Spec {
+	spec := newMinimalLightClientSpec()
+	spec.CAPELLA_FORK_EPOCH = 8
+	spec.DENEB_FORK_EPOCH = 8
+	spec.ELECTRA_FORK_EPOCH = 16
+	return spec
+}
+
+func loadLightClientTestdata(t *testing.T, testdataFile string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(testdataFile)
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("failed to decode test data: %v", err)
+	}
+}
+
+func TestLightClientStore_Testdata(t *testing.T) {
+	spec := newLightClientStoreTestdataSpec()
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+
+	var bootstrap LightClientBootstrapResponse
+	loadLightClientTestdata(t, "testdata/light_client_store.bootstrap.json", &bootstrap)
+	var updates []LightClientUpdateResponse
+	loadLightClientTestdata(t, "testdata/light_client_store.updates.json", &updates)
+
+	trustedBlockRoot := common.HexToHash("0x2af5dbfe9ba99435de61879e93965ca7e42d76ddfe3dc207ea7b0e658927d6ae")
+	store, err := NewLightClientStore(spec, genesis, trustedBlockRoot, &bootstrap.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		version        ConsensusVersion
+		finalizedRoot  string
+		optimisticRoot string
+	}{
+		{
+			version:        ConsensusVersionAltair,
+			finalizedRoot:  "0x79582f086cde0cea3500d499d14433ec52e2abfa790f09ed9d7fb7cc9794f8fb",
+			optimisticRoot: "0x86352474a9cb34109c338b33a44bdbe7822b03320de6d7913279eabf62ba2e5d",
+		},
+		{
+			version:        ConsensusVersionDeneb,
+			finalizedRoot:  "0x27761ffa58a8390d70bd7a732a2471f0784a66a53a50af5c4aac498188c42e28",
+			optimisticRoot: "0x3793fae9ae9ef1e695c5ced392cce46c94d0b0cb3d6a201f8ebd2e370e311081",
+		},
+		{
+			// full participation is not above the safety threshold of two fully participating periods
+			version:        ConsensusVersionElectra,
+			finalizedRoot:  "0x61ba085bdc5c6cc4fdf969e366d9dd13ff46757e7ed85520f227c1c5070ccc14",
+			optimisticRoot: "0x61ba085bdc5c6cc4fdf969e366d9dd13ff46757e7ed85520f227c1c5070ccc14",
+		},
+		{
+			// an update without finality and supermajority is only kept as the best valid update
+			version:        ConsensusVersionElectra,
+			finalizedRoot:  "0x61ba085bdc5c6cc4fdf969e366d9dd13ff46757e7ed85520f227c1c5070ccc14",
+			optimisticRoot: "0x61ba085bdc5c6cc4fdf969e366d9dd13ff46757e7ed85520f227c1c5070ccc14",
+		},
+	}
+	if len(updates) != len(tests) {
+		t.Fatalf("expected %d updates, got %d", len(tests), len(updates))
+	}
+	for i, tt := range tests {
+		update := &updates[i].Data
+		if updates[i].Version != tt.version {
+			t.Errorf("update %d: expected version %s, got %s", i, tt.version, updates[i].Version)
+		}
+		if (update.AttestedHeader.Execution == nil) != (tt.version == ConsensusVersionAltair) {
+			t.Errorf("update %d: unexpected %s header shape", i, tt.version)
+		}
+		if err := store.ProcessUpdate(update, update.SignatureSlot+1); err != nil {
+			t.Fatalf("update %d: unexpected error: %v", i, err)
+		}
+
+		finalized, optimistic := store.FinalizedHeader(), store.OptimisticHeader()
+		if root := finalized.Beacon.HashTreeRoot(); root.Hex() != tt.finalizedRoot {
+			t.Errorf("update %d: expected finalized header %s, got %s", i, tt.finalizedRoot, root)
+		}
+		if root := optimistic.Beacon.HashTreeRoot(); root.Hex() != tt.optimisticRoot {
+			t.Errorf("update %d: expected optimistic header %s, got %s", i, tt.optimisticRoot, root)
+		}
+	}
+
+	// the best valid update is forced once a sync committee period passed without finality
+	if store.ProcessForceUpdate(130 + 64) {
+		t.Error("expected no forced update before the update timeout")
+	}
+	if !store.ProcessForceUpdate(256) {
+		t.Fatal("expected a forced update after the update timeout")
+	}
+	finalized, optimistic := store.FinalizedHeader(), store.OptimisticHeader()
+	want := "0x33b3a631a35e1f929ba141c6f30f7060984fc8f5786d5e905dc8a848ff6d8792"
+	if root := finalized.Beacon.HashTreeRoot(); root.Hex() != want || root != updates[3].Data.AttestedHeader.Beacon.HashTreeRoot() {
+		t.Errorf("expected the attested header %s as finalized header, got %s", want, root)
+	}
+	if root := optimistic.Beacon.HashTreeRoot(); root.Hex() != want {
+		t.Errorf("expected optimistic header %s, got %s", want, root)
+	}
+	if store.Period() != 3 {
+		t.Errorf("expected period 3, got %d", store.Period())
+	}
+	current := store.CurrentSyncCommittee()
+	if current.AggregatePubkey != updates[2].Data.NextSyncCommittee.AggregatePubkey {
+		t.Error("expected the sync committee of period 3 as current sync committee")
+	}
+}
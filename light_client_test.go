@@ -0,0 +1,775 @@
+package beaconclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/view"
+)
+
+// encodeLightClientSSZ encodes a light client container with the SSZ shape of version to serve it in tests
+func encodeLightClientSSZ(spec *Spec, version ConsensusVersion, obj lightClientObject) ([]byte, error) {
+	fork, err := lightClientForkOf(version)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := obj.sszFields(&spec.Spec, fork)
+	serializables := make([]codec.Serializable, len(fields))
+	for i, field := range fields {
+		if serializables[i], err = testSerializable(field); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	if err := codec.NewEncodingWriter(&buf).Container(serializables...); err != nil {
+		return nil, fmt.Errorf("failed to encode %s light client data: %w", version, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// testSerializable returns the encoder of a light client container field
+func testSerializable(field codec.Deserializable) (codec.Serializable, error) {
+	switch field := field.(type) {
+	case lightClientHeaderSSZ:
+		return testLightClientHeaderSSZ{field}, nil
+	case merkleBranchSSZ:
+		return testMerkleBranchSSZ{field}, nil
+	case codec.Serializable:
+		return field, nil
+	default:
+		return nil, fmt.Errorf("unexpected light client field %T", field)
+	}
+}
+
+// testLightClientHeaderSSZ encodes a LightClientHeader with the SSZ shape of a fork
+type testLightClientHeaderSSZ struct {
+	lightClientHeaderSSZ
+}
+
+func (h testLightClientHeaderSSZ) fields() ([]codec.Serializable, error) {
+	if h.fork.newExecutionHeader() == nil {
+		return nil, nil
+	}
+	if h.header.Execution == nil {
+		return nil, fmt.Errorf("%s light client header without execution payload header", h.fork.version)
+	}
+	execution, err := h.header.Execution.field()
+	if err != nil {
+		return nil, err
+	}
+	branch := testMerkleBranchSSZ{merkleBranchSSZ{&h.header.ExecutionBranch, executionBranchDepth}}
+	return []codec.Serializable{&h.header.Beacon, execution.(codec.Serializable), branch}, nil
+}
+
+func (h testLightClientHeaderSSZ) Serialize(w *codec.EncodingWriter) error {
+	fields, err := h.fields()
+	if err != nil {
+		return err
+	}
+	if fields == nil {
+		return w.FixedLenContainer(&h.header.Beacon)
+	}
+	return w.Container(fields...)
+}
+
+func (h testLightClientHeaderSSZ) ByteLength() uint64 {
+	fields, err := h.fields()
+	if err != nil || fields == nil {
+		return h.header.Beacon.FixedLength()
+	}
+	return codec.ContainerLength(fields...)
+}
+
+// testMerkleBranchSSZ encodes a Merkle branch as Vector[Bytes32, depth]
+type testMerkleBranchSSZ struct {
+	merkleBranchSSZ
+}
+
+func (b testMerkleBranchSSZ) Serialize(w *codec.EncodingWriter) error {
+	if uint64(len(*b.branch)) != b.depth {
+		return fmt.Errorf("expected Merkle branch of depth %d, got %d", b.depth, len(*b.branch))
+	}
+	for _, node := range *b.branch {
+		if err := w.Write(node[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b testMerkleBranchSSZ) ByteLength() uint64 {
+	return b.depth * 32
+}
+
+func newTestBranch(depth int, seed byte) []common.Hash {
+	branch := make([]common.Hash, depth)
+	for i := range branch {
+		branch[i] = common.Hash{seed, byte(i + 1)}
+	}
+	return branch
+}
+
+func newTestLightClientHeader(t *testing.T, version ConsensusVersion, slot uint64) LightClientHeader {
+	t.Helper()
+	fork, err := lightClientForkOf(version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	header := LightClientHeader{
+		Beacon: BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: 42,
+			ParentRoot:    common.Hash{0x01},
+			StateRoot:     common.Hash{0x02},
+			BodyRoot:      common.Hash{0x03},
+		},
+	}
+	if execution := fork.newExecutionHeader(); execution != nil {
+		if execution.Capella != nil {
+			execution.Capella.BlockNumber = view.Uint64View(slot)
+			execution.Capella.BlockHash = zrntcommon.Hash32{0x04}
+			execution.Capella.ExtraData = []byte("capella")
+		} else {
+			execution.Deneb.BlockNumber = view.Uint64View(slot)
+			execution.Deneb.BlockHash = zrntcommon.Hash32{0x05}
+			execution.Deneb.ExtraData = []byte("deneb")
+			execution.Deneb.BlobGasUsed = 131072
+		}
+		header.Execution = execution
+		header.ExecutionBranch = newTestBranch(executionBranchDepth, 0x06)
+	}
+	return header
+}
+
+func newTestSyncCommittee(spec *Spec) zrntcommon.SyncCommittee {
+	pubkeys := make(zrntcommon.SyncCommitteePubkeys, spec.SYNC_COMMITTEE_SIZE)
+	for i := range pubkeys {
+		pubkeys[i][0] = byte(i)
+	}
+	return zrntcommon.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: zrntcommon.BLSPubkey{0xaa}}
+}
+
+func newTestSyncAggregate(spec *Spec) altair.SyncAggregate {
+	bits := make(altair.SyncCommitteeBits, spec.SYNC_COMMITTEE_SIZE/8)
+	bits[0] = 0xff
+	return altair.SyncAggregate{SyncCommitteeBits: bits, SyncCommitteeSignature: zrntcommon.BLSSignature{0xbb}}
+}
+
+func newTestLightClientUpdate(t *testing.T, spec *Spec, version ConsensusVersion, slot uint64) LightClientUpdate {
+	t.Helper()
+	fork, err := lightClientForkOf(version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return LightClientUpdate{
+		AttestedHeader:          newTestLightClientHeader(t, version, slot),
+		NextSyncCommittee:       newTestSyncCommittee(spec),
+		NextSyncCommitteeBranch: newTestBranch(int(fork.syncCommitteeBranchDepth), 0x07),
+		FinalizedHeader:         newTestLightClientHeader(t, version, slot-64),
+		FinalityBranch:          newTestBranch(int(fork.finalityBranchDepth), 0x08),
+		SyncAggregate:           newTestSyncAggregate(spec),
+		SignatureSlot:           slot + 1,
+	}
+}
+
+func TestGetLightClientBootstrap(t *testing.T) {
+	spec := newMainnetSpec()
+	blockRoot := common.HexToHash("0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2")
+	want := LightClientBootstrapResponse{
+		Version: ConsensusVersionElectra,
+		Data: LightClientBootstrap{
+			Header:                     newTestLightClientHeader(t, ConsensusVersionElectra, 364032*32),
+			CurrentSyncCommittee:       newTestSyncCommittee(spec),
+			CurrentSyncCommitteeBranch: newTestBranch(6, 0x09),
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/light_client/bootstrap/"+blockRoot.Hex() {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(want)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	bootstrap, err := client.GetLightClientBootstrap(context.Background(), blockRoot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*bootstrap, want) {
+		t.Errorf("unexpected bootstrap: %+v", bootstrap)
+	}
+	if bootstrap.Data.Header.Execution.Deneb == nil {
+		t.Error("expected deneb execution payload header")
+	}
+}
+
+func TestGetLightClientBootstrapSSZ(t *testing.T) {
+	spec := newMainnetSpec()
+	want := LightClientBootstrapResponse{
+		Version: ConsensusVersionCapella,
+		Data: LightClientBootstrap{
+			Header:                     newTestLightClientHeader(t, ConsensusVersionCapella, 194048*32),
+			CurrentSyncCommittee:       newTestSyncCommittee(spec),
+			CurrentSyncCommitteeBranch: newTestBranch(5, 0x09),
+		},
+	}
+	data, err := encodeLightClientSSZ(spec, want.Version, &want.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "application/octet-stream") {
+			t.Errorf("unexpected Accept header: %s", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Eth-Consensus-Version", "capella")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	bootstrap, err := client.GetLightClientBootstrapSSZ(context.Background(), spec, common.Hash{0x01})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*bootstrap, want) {
+		t.Errorf("unexpected bootstrap: %+v", bootstrap)
+	}
+
+	if _, err := client.GetLightClientBootstrapSSZ(context.Background(), nil, common.Hash{0x01}); err == nil {
+		t.Error("expected error without spec")
+	}
+}
+
+func TestGetLightClientUpdates(t *testing.T) {
+	spec := newMainnetSpec()
+	want := []LightClientUpdateResponse{
+		{Version: ConsensusVersionDeneb, Data: newTestLightClientUpdate(t, spec, ConsensusVersionDeneb, 269568*32)},
+		{Version: ConsensusVersionElectra, Data: newTestLightClientUpdate(t, spec, ConsensusVersionElectra, 364032*32)},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/light_client/updates" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("start_period") != "1316" || r.URL.Query().Get("count") != "2" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(want)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	updates, err := client.GetLightClientUpdates(context.Background(), 1316, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(updates, want) {
+		t.Errorf("unexpected updates: %+v", updates)
+	}
+}
+
+func TestGetLightClientUpdatesSSZ(t *testing.T) {
+	spec := newMainnetSpec()
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+	want := []LightClientUpdateResponse{
+		{Version: ConsensusVersionDeneb, Data: newTestLightClientUpdate(t, spec, ConsensusVersionDeneb, 269568*32)},
+		// an update after the first blob parameter change of Fulu
+		{Version: ConsensusVersionFulu, Data: newTestLightClientUpdate(t, spec, ConsensusVersionFulu, 412672*32)},
+	}
+
+	var body []byte
+	for _, update := range want {
+		digest, err := ComputeForkDigest(spec, genesis, ComputeEpochAtSlot(spec, update.Data.AttestedHeader.Beacon.Slot))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, err := encodeLightClientSSZ(spec, update.Version, &update.Data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body = binary.LittleEndian.AppendUint64(body, uint64(len(digest)+len(data)))
+		body = append(body, digest[:]...)
+		body = append(body, data...)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{"genesis_time":"1606824023","genesis_validators_root":"` + testGenesisValidatorsRoot + `","genesis_fork_version":"0x00000000"}}`))
+		case "/eth/v1/beacon/light_client/updates":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(body)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	updates, err := client.GetLightClientUpdatesSSZ(context.Background(), spec, 1316, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(updates, want) {
+		t.Errorf("unexpected updates: %+v", updates)
+	}
+}
+
+func TestDecodeLightClientUpdatesSSZ_Invalid(t *testing.T) {
+	spec := newMainnetSpec()
+	versions, err := lightClientVersionsByDigest(spec, &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "truncated length", data: []byte{0x01, 0x02}},
+		{name: "length beyond body", data: binary.LittleEndian.AppendUint64(nil, 100)},
+		{name: "unknown fork digest", data: append(binary.LittleEndian.AppendUint64(nil, 4), 0xde, 0xad, 0xbe, 0xef)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeLightClientUpdatesSSZ(spec, versions, tt.data); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	updates, err := decodeLightClientUpdatesSSZ(spec, versions, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updates) != 0 {
+		t.Errorf("expected no updates, got %d", len(updates))
+	}
+}
+
+func TestGetLightClientFinalityUpdate(t *testing.T) {
+	spec := newMainnetSpec()
+	// bellatrix updates still use the altair header without execution payload header
+	want := LightClientFinalityUpdateResponse{
+		Version: ConsensusVersionBellatrix,
+		Data: LightClientFinalityUpdate{
+			AttestedHeader:  newTestLightClientHeader(t, ConsensusVersionBellatrix, 144896*32),
+			FinalizedHeader: newTestLightClientHeader(t, ConsensusVersionBellatrix, 144896*32-64),
+			FinalityBranch:  newTestBranch(6, 0x08),
+			SyncAggregate:   newTestSyncAggregate(spec),
+			SignatureSlot:   144896*32 + 1,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/light_client/finality_update" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(want)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	update, err := client.GetLightClientFinalityUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*update, want) {
+		t.Errorf("unexpected update: %+v", update)
+	}
+	if update.Data.AttestedHeader.Execution != nil {
+		t.Error("expected no execution payload header")
+	}
+}
+
+func TestGetLightClientFinalityUpdateSSZ(t *testing.T) {
+	spec := newMainnetSpec()
+	want := LightClientFinalityUpdateResponse{
+		Version: ConsensusVersionElectra,
+		Data: LightClientFinalityUpdate{
+			AttestedHeader:  newTestLightClientHeader(t, ConsensusVersionElectra, 364032*32),
+			FinalizedHeader: newTestLightClientHeader(t, ConsensusVersionElectra, 364032*32-64),
+			FinalityBranch:  newTestBranch(7, 0x08),
+			SyncAggregate:   newTestSyncAggregate(spec),
+			SignatureSlot:   364032*32 + 1,
+		},
+	}
+	data, err := encodeLightClientSSZ(spec, want.Version, &want.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Eth-Consensus-Version", "electra")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	update, err := client.GetLightClientFinalityUpdateSSZ(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*update, want) {
+		t.Errorf("unexpected update: %+v", update)
+	}
+
+	// a pre-electra finality branch does not have the electra depth
+	want.Data.FinalityBranch = newTestBranch(6, 0x08)
+	if _, err := encodeLightClientSSZ(spec, want.Version, &want.Data); err == nil {
+		t.Error("expected error for a branch of the wrong depth")
+	}
+}
+
+func TestGetLightClientOptimisticUpdate(t *testing.T) {
+	spec := newMainnetSpec()
+	want := LightClientOptimisticUpdateResponse{
+		Version: ConsensusVersionAltair,
+		Data: LightClientOptimisticUpdate{
+			AttestedHeader: newTestLightClientHeader(t, ConsensusVersionAltair, 74240*32),
+			SyncAggregate:  newTestSyncAggregate(spec),
+			SignatureSlot:  74240*32 + 1,
+		},
+	}
+	data, err := encodeLightClientSSZ(spec, want.Version, &want.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/light_client/optimistic_update" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if strings.Contains(r.Header.Get("Accept"), "application/octet-stream") {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Eth-Consensus-Version", "altair")
+			_, _ = w.Write(data)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(want)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	update, err := client.GetLightClientOptimisticUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*update, want) {
+		t.Errorf("unexpected JSON update: %+v", update)
+	}
+
+	update, err = client.GetLightClientOptimisticUpdateSSZ(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*update, want) {
+		t.Errorf("unexpected SSZ update: %+v", update)
+	}
+}
+
+func TestGetLightClientOptimisticUpdateSSZ_JSONFallback(t *testing.T) {
+	spec := newMainnetSpec()
+	want := LightClientOptimisticUpdateResponse{
+		Version: ConsensusVersionDeneb,
+		Data: LightClientOptimisticUpdate{
+			AttestedHeader: newTestLightClientHeader(t, ConsensusVersionDeneb, 269568*32),
+			SyncAggregate:  newTestSyncAggregate(spec),
+			SignatureSlot:  269568*32 + 1,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(want)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	update, err := client.GetLightClientOptimisticUpdateSSZ(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*update, want) {
+		t.Errorf("unexpected update: %+v", update)
+	}
+}
+
+func TestGetLightClientOptimisticUpdateSSZ_UnsupportedVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Eth-Consensus-Version", "phase0")
+		_, _ = w.Write([]byte{0x00})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.GetLightClientOptimisticUpdateSSZ(context.Background(), newMainnetSpec()); err == nil {
+		t.Fatal("expected error for phase0 light client data")
+	}
+}
+
+func TestExecutionPayloadHeader(t *testing.T) {
+	capellaHeader := newTestLightClientHeader(t, ConsensusVersionCapella, 100).Execution
+	denebHeader := newTestLightClientHeader(t, ConsensusVersionDeneb, 200).Execution
+
+	if capellaHeader.BlockNumber() != 100 || capellaHeader.BlockHash() != (common.Hash{0x04}) {
+		t.Errorf("unexpected capella header: %d %s", capellaHeader.BlockNumber(), capellaHeader.BlockHash())
+	}
+	if denebHeader.BlockNumber() != 200 || denebHeader.BlockHash() != (common.Hash{0x05}) {
+		t.Errorf("unexpected deneb header: %d %s", denebHeader.BlockNumber(), denebHeader.BlockHash())
+	}
+	if capellaHeader.HashTreeRoot() == denebHeader.HashTreeRoot() {
+		t.Error("expected different hash tree roots")
+	}
+
+	var empty ExecutionPayloadHeader
+	if empty.HashTreeRoot() != (common.Hash{}) {
+		t.Error("expected zero hash tree root for an empty header")
+	}
+}
+
+// The light client testdata is built from the mainnet blocks in testdata: the headers carry the
+// beacon block headers, execution payload headers and execution branches of those blocks, while the
+// sync committees, state branches and sync aggregates stand in for the mainnet state.
+
+// newLightClientTestdataServer serves the testdata file of each path, in SSZ if the request accepts it
+func newLightClientTestdataServer(t *testing.T, version ConsensusVersion, files map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v1/beacon/genesis" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{"genesis_time":"1606824023","genesis_validators_root":"` + testGenesisValidatorsRoot + `","genesis_fork_version":"0x00000000"}}`))
+			return
+		}
+		file, ok := files[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		contentType := "application/json"
+		if strings.HasPrefix(r.Header.Get("Accept"), contentTypeSSZ) {
+			file, contentType = strings.TrimSuffix(file, ".json")+".ssz", contentTypeSSZ
+			if version != "" {
+				w.Header().Set("Eth-Consensus-Version", string(version))
+			}
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Errorf("failed to read test data: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(data)
+	}))
+}
+
+// checkLightClientHeader checks that a light client header is the valid header of a testdata block
+func checkLightClientHeader(t *testing.T, spec *Spec, name string, header *LightClientHeader, blockFile string) {
+	t.Helper()
+	block := loadVersionedBlock(t, blockFile)
+	if root := header.Beacon.HashTreeRoot(); root != testBlockRoot(t, spec, block) {
+		t.Errorf("%s: header root %s does not match the block root %s of %s", name, root, testBlockRoot(t, spec, block), blockFile)
+	}
+	payload, _ := block.ExecutionPayload()
+	if header.Execution == nil || header.Execution.BlockHash() != payload.BlockHash() {
+		t.Errorf("%s: unexpected execution payload header of %s", name, blockFile)
+	}
+	if !isValidLightClientHeader(spec, header) {
+		t.Errorf("%s: invalid execution branch against the body root of %s", name, blockFile)
+	}
+}
+
+func TestGetLightClientBootstrap_Testdata(t *testing.T) {
+	spec := newMainnetSpec()
+	block := loadVersionedBlock(t, "testdata/electra.block.json")
+	blockRoot := testBlockRoot(t, spec, block)
+	server := newLightClientTestdataServer(t, ConsensusVersionElectra, map[string]string{
+		"/eth/v1/beacon/light_client/bootstrap/" + blockRoot.Hex(): "testdata/electra.light_client_bootstrap.json",
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	bootstrap, err := client.GetLightClientBootstrap(context.Background(), blockRoot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bootstrapSSZ, err := client.GetLightClientBootstrapSSZ(context.Background(), spec, blockRoot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(bootstrap, bootstrapSSZ) {
+		t.Error("expected the JSON and SSZ bootstraps to match")
+	}
+
+	if bootstrap.Version != ConsensusVersionElectra {
+		t.Errorf("unexpected version: %s", bootstrap.Version)
+	}
+	checkLightClientHeader(t, spec, "header", &bootstrap.Data.Header, "testdata/electra.block.json")
+	if len(bootstrap.Data.CurrentSyncCommitteeBranch) != 6 {
+		t.Errorf("expected an electra current sync committee branch of depth 6, got %d", len(bootstrap.Data.CurrentSyncCommitteeBranch))
+	}
+	if uint64(len(bootstrap.Data.CurrentSyncCommittee.Pubkeys)) != uint64(spec.SYNC_COMMITTEE_SIZE) {
+		t.Errorf("unexpected sync committee size: %d", len(bootstrap.Data.CurrentSyncCommittee.Pubkeys))
+	}
+
+	want, err := os.ReadFile("testdata/electra.light_client_bootstrap.ssz")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	if data, err := encodeLightClientSSZ(spec, bootstrap.Version, &bootstrap.Data); err != nil || !bytes.Equal(data, want) {
+		t.Errorf("expected the bootstrap to encode to the testdata, err: %v", err)
+	}
+}
+
+func TestGetLightClientUpdates_Testdata(t *testing.T) {
+	spec := newMainnetSpec()
+	server := newLightClientTestdataServer(t, "", map[string]string{
+		"/eth/v1/beacon/light_client/updates": "testdata/light_client_updates.json",
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	updates, err := client.GetLightClientUpdates(context.Background(), 1404, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updatesSSZ, err := client.GetLightClientUpdatesSSZ(context.Background(), spec, 1404, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(updates, updatesSSZ) {
+		t.Error("expected the JSON and SSZ updates to match")
+	}
+
+	tests := []struct {
+		version        ConsensusVersion
+		attestedBlock  string
+		finalizedBlock string
+		// the sync committee and finality branch depths
+		depths [2]int
+	}{
+		// a capella header in the deneb shape is hashed as the capella header it was upgraded from
+		{ConsensusVersionDeneb, "testdata/deneb.block.json", "testdata/cepella.block.json", [2]int{5, 6}},
+		{ConsensusVersionElectra, "testdata/electra.block.json", "testdata/deneb.block.json", [2]int{6, 7}},
+		{ConsensusVersionFulu, "testdata/fulu.block.json", "testdata/electra.block.json", [2]int{6, 7}},
+	}
+	if len(updates) != len(tests) {
+		t.Fatalf("expected %d updates, got %d", len(tests), len(updates))
+	}
+	for i, tt := range tests {
+		update := &updates[i].Data
+		if updates[i].Version != tt.version {
+			t.Errorf("update %d: expected version %s, got %s", i, tt.version, updates[i].Version)
+		}
+		checkLightClientHeader(t, spec, "attested header", &update.AttestedHeader, tt.attestedBlock)
+		checkLightClientHeader(t, spec, "finalized header", &update.FinalizedHeader, tt.finalizedBlock)
+		if depths := [2]int{len(update.NextSyncCommitteeBranch), len(update.FinalityBranch)}; depths != tt.depths {
+			t.Errorf("update %d: expected branch depths %v, got %v", i, tt.depths, depths)
+		}
+	}
+}
+
+func TestLightClientVersionsByDigest_Testdata(t *testing.T) {
+	spec := newMainnetSpec()
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+	versions, err := lightClientVersionsByDigest(spec, genesis)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile("testdata/light_client_updates.ssz")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	var digests []zrntcommon.ForkDigest
+	for len(data) > 0 {
+		var digest zrntcommon.ForkDigest
+		copy(digest[:], data[8:])
+		digests = append(digests, digest)
+		data = data[8+binary.LittleEndian.Uint64(data):]
+	}
+
+	// the mainnet fork digests of Deneb and Electra, the Fulu update is signed after the first blob parameter change
+	want := []string{"0x6a95a1a9", "0xad532ceb", ""}
+	wantVersions := []ConsensusVersion{ConsensusVersionDeneb, ConsensusVersionElectra, ConsensusVersionFulu}
+	if len(digests) != len(want) {
+		t.Fatalf("expected %d chunks, got %d", len(want), len(digests))
+	}
+	for i, digest := range digests {
+		if want[i] != "" && digest.String() != want[i] {
+			t.Errorf("chunk %d: expected fork digest %s, got %s", i, want[i], digest)
+		}
+		if versions[digest] != wantVersions[i] {
+			t.Errorf("chunk %d: expected fork digest %s of %s, got %q", i, digest, wantVersions[i], versions[digest])
+		}
+	}
+	fulu, err := ComputeForkDigest(spec, genesis, 411392)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if digests[2] == fulu {
+		t.Error("expected the fork digest of the first blob parameter change, not of the fulu fork")
+	}
+}
+
+func TestGetLightClientFinalityUpdate_Testdata(t *testing.T) {
+	spec := newMainnetSpec()
+	server := newLightClientTestdataServer(t, ConsensusVersionFulu, map[string]string{
+		"/eth/v1/beacon/light_client/finality_update": "testdata/fulu.light_client_finality_update.json",
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	update, err := client.GetLightClientFinalityUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updateSSZ, err := client.GetLightClientFinalityUpdateSSZ(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(update, updateSSZ) {
+		t.Error("expected the JSON and SSZ finality updates to match")
+	}
+
+	if update.Version != ConsensusVersionFulu {
+		t.Errorf("unexpected version: %s", update.Version)
+	}
+	checkLightClientHeader(t, spec, "attested header", &update.Data.AttestedHeader, "testdata/fulu.block.json")
+	checkLightClientHeader(t, spec, "finalized header", &update.Data.FinalizedHeader, "testdata/electra.block.json")
+	if len(update.Data.FinalityBranch) != 7 {
+		t.Errorf("expected a fulu finality branch of depth 7, got %d", len(update.Data.FinalityBranch))
+	}
+	if update.Data.SignatureSlot != update.Data.AttestedHeader.Beacon.Slot+1 {
+		t.Errorf("unexpected signature slot: %d", update.Data.SignatureSlot)
+	}
+}
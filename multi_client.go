@@ -0,0 +1,260 @@
+package beaconclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MultiClient is a beacon node API client backed by several beacon nodes
+//
+// Requests are routed to the best ranked node. If a node fails with a network
+// error or a 5xx response, read requests, including read-only POST queries such as
+// GetValidators with many ids, are retried on the next node, while the error of
+// other requests such as pool submissions is returned, as the failed node
+// may already have accepted them. Other API errors such as 404 are returned
+// directly as *APIError.
+//
+// Nodes are ranked by Refresh, which queries GetHealth and GetSyncingStatus of
+// every node. Call Refresh once after creation and then periodically, or use Run.
+type MultiClient struct {
+	*Client
+}
+
+// NodeStatus describes the last known status of a node in a MultiClient
+type NodeStatus struct {
+	// BaseURL is the base URL of the node
+	BaseURL string
+	// Health is the health status reported by the node (0 if unknown)
+	Health HealthStatus
+	// Syncing is the syncing status reported by the node (nil if unknown)
+	Syncing *SyncingStatus
+	// Err is the error of the last health check or request, if any
+	Err error
+}
+
+// NewMultiClient creates a new beacon node API client with failover between the given nodes
+// The options are applied to the client of every node
+func NewMultiClient(baseURLs []string, opts ...Option) (*MultiClient, error) {
+	if len(baseURLs) == 0 {
+		return nil, errors.New("at least one base URL is required")
+	}
+
+	pool := &nodePool{}
+	for _, baseURL := range baseURLs {
+		pool.nodes = append(pool.nodes, &poolNode{client: NewClient(baseURL, opts...)})
+	}
+
+	client := NewClient(baseURLs[0], opts...)
+	client.pool = pool
+	return &MultiClient{Client: client}, nil
+}
+
+// Refresh checks health and syncing status of every node concurrently and re-ranks them
+//
+// Ready nodes are preferred over syncing nodes, and nodes with a smaller sync
+// distance are preferred over nodes with a larger one. Nodes that failed the
+// health check or a previous request are only used as a last resort, until they
+// answer a request again.
+func (m *MultiClient) Refresh(ctx context.Context) {
+	m.pool.refresh(ctx)
+}
+
+// defaultRefreshInterval is the interval used by Run if none is given
+const defaultRefreshInterval = 30 * time.Second
+
+// Run refreshes the node ranking every interval until ctx is cancelled
+// An interval <= 0 falls back to a refresh every 30 seconds.
+func (m *MultiClient) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		m.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Nodes returns the status of all nodes, best ranked first
+func (m *MultiClient) Nodes() []NodeStatus {
+	nodes := m.pool.ordered()
+	statuses := make([]NodeStatus, len(nodes))
+	for i, node := range nodes {
+		statuses[i] = node.status()
+	}
+	return statuses
+}
+
+// poolNode is a single node of a nodePool
+type poolNode struct {
+	client *Client
+
+	mu      sync.Mutex
+	health  HealthStatus
+	syncing *SyncingStatus
+	err     error
+}
+
+func (n *poolNode) status() NodeStatus {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return NodeStatus{BaseURL: n.client.baseURL, Health: n.health, Syncing: n.syncing, Err: n.err}
+}
+
+// rank returns the rank of the node and its sync distance, lower is better
+func (n *poolNode) rank() (int, uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var distance uint64
+	if n.syncing != nil {
+		distance = n.syncing.SyncDistance
+	}
+	switch {
+	case n.err != nil:
+		return 3, distance
+	case n.health == HealthStatusReady:
+		return 0, distance
+	case n.health == HealthStatusSyncing:
+		return 1, distance
+	default:
+		return 2, distance
+	}
+}
+
+func (n *poolNode) setError(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.err = err
+}
+
+// clearError clears the error of the node and reports whether it had one
+func (n *poolNode) clearError() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	hadError := n.err != nil
+	n.err = nil
+	return hadError
+}
+
+func (n *poolNode) check(ctx context.Context) {
+	health, err := n.client.GetHealth(ctx)
+	var syncing *SyncingStatus
+	if err == nil {
+		syncing, err = n.client.GetSyncingStatus(ctx)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.health = health
+	n.syncing = syncing
+	n.err = err
+}
+
+// nodePool routes requests to a ranked list of nodes
+type nodePool struct {
+	mu    sync.RWMutex
+	nodes []*poolNode
+}
+
+func (p *nodePool) refresh(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, node := range p.nodes {
+		wg.Go(func() {
+			node.check(ctx)
+		})
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sortLocked()
+}
+
+func (p *nodePool) sortLocked() {
+	slices.SortStableFunc(p.nodes, func(a, b *poolNode) int {
+		rankA, distanceA := a.rank()
+		rankB, distanceB := b.rank()
+		if rankA != rankB {
+			return rankA - rankB
+		}
+		switch {
+		case distanceA < distanceB:
+			return -1
+		case distanceA > distanceB:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+func (p *nodePool) ordered() []*poolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return slices.Clone(p.nodes)
+}
+
+func (p *nodePool) best() *Client {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.nodes[0].client
+}
+
+// demote marks the node as failed and moves it behind all healthy nodes
+func (p *nodePool) demote(node *poolNode, err error) {
+	node.setError(err)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sortLocked()
+}
+
+// promote clears the error of a node that answered again and restores its rank
+func (p *nodePool) promote(node *poolNode) {
+	if !node.clearError() {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sortLocked()
+}
+
+// do sends the request to the nodes in rank order until one of them answers
+// Only idempotent requests fail over, others may already have been accepted by the failed node.
+func (p *nodePool) do(ctx context.Context, req *request) (*response, error) {
+	var lastErr error
+	for _, node := range p.ordered() {
+		resp, err := node.client.doWithRetry(ctx, req)
+		if err == nil {
+			p.promote(node)
+			return resp, nil
+		}
+		if ctx.Err() != nil || !isNodeFailure(resp) {
+			return nil, err
+		}
+		p.demote(node, err)
+		if !req.idempotent {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// isNodeFailure reports whether a failed request indicates a failure of the node
+// resp is nil if the request failed before a response was received
+func isNodeFailure(resp *response) bool {
+	return resp == nil || resp.statusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,265 @@
+package beaconclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+)
+
+// newTestNode starts a beacon node stub reporting the given health and sync distance
+// All other endpoints answer with the given status code
+func newTestNode(t *testing.T, health int, syncDistance string, status int, calls *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/node/health":
+			w.WriteHeader(health)
+		case "/eth/v1/node/syncing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"data": {"head_slot": "100", "sync_distance": "` + syncDistance + `", "is_syncing": false}}`))
+		default:
+			calls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			switch status {
+			case http.StatusOK:
+				_, _ = w.Write([]byte(`{"data": {"version": "` + r.Host + `"}}`))
+			case http.StatusNotFound:
+				_, _ = w.Write([]byte(`{"code": 404, "message": "Not found"}`))
+			default:
+				_, _ = w.Write([]byte(`{"code": 503, "message": "Unavailable"}`))
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewMultiClient_NoURLs(t *testing.T) {
+	if _, err := NewMultiClient(nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestMultiClient_Refresh(t *testing.T) {
+	var calls atomic.Int32
+	syncing := newTestNode(t, http.StatusPartialContent, "10", http.StatusOK, &calls)
+	lagging := newTestNode(t, http.StatusOK, "2", http.StatusOK, &calls)
+	ready := newTestNode(t, http.StatusOK, "0", http.StatusOK, &calls)
+
+	client, err := NewMultiClient([]string{syncing.URL, lagging.URL, ready.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.Refresh(context.Background())
+
+	nodes := client.Nodes()
+	want := []string{ready.URL, lagging.URL, syncing.URL}
+	for i, node := range nodes {
+		if node.BaseURL != want[i] {
+			t.Errorf("node %d: got %s, want %s", i, node.BaseURL, want[i])
+		}
+	}
+
+	version, err := client.GetNodeVersion(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if "http://"+version.Version != ready.URL {
+		t.Errorf("expected request to be routed to %s, got %s", ready.URL, version.Version)
+	}
+
+	health, err := client.GetHealth(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if health != HealthStatusReady {
+		t.Errorf("expected status 200, got %d", health)
+	}
+}
+
+func TestMultiClient_Failover(t *testing.T) {
+	var failingCalls, healthyCalls atomic.Int32
+	failing := newTestNode(t, http.StatusOK, "0", http.StatusServiceUnavailable, &failingCalls)
+	healthy := newTestNode(t, http.StatusOK, "0", http.StatusOK, &healthyCalls)
+
+	client, err := NewMultiClient([]string{failing.URL, healthy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range 2 {
+		version, err := client.GetNodeVersion(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if "http://"+version.Version != healthy.URL {
+			t.Errorf("expected request to fail over to %s, got %s", healthy.URL, version.Version)
+		}
+	}
+
+	// the failing node is demoted after the first request
+	if failingCalls.Load() != 1 {
+		t.Errorf("expected 1 call to the failing node, got %d", failingCalls.Load())
+	}
+	if nodes := client.Nodes(); nodes[1].BaseURL != failing.URL || nodes[1].Err == nil {
+		t.Errorf("expected failing node to be demoted, got %+v", nodes)
+	}
+}
+
+func TestMultiClient_NetworkErrorFailover(t *testing.T) {
+	var calls atomic.Int32
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	healthy := newTestNode(t, http.StatusOK, "0", http.StatusOK, &calls)
+
+	client, err := NewMultiClient([]string{down.URL, healthy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetNodeVersion(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMultiClient_NotFoundNoFailover(t *testing.T) {
+	var firstCalls, secondCalls atomic.Int32
+	first := newTestNode(t, http.StatusOK, "0", http.StatusNotFound, &firstCalls)
+	second := newTestNode(t, http.StatusOK, "0", http.StatusOK, &secondCalls)
+
+	client, err := NewMultiClient([]string{first.URL, second.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.GetBlock(context.Background(), "999999999")
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Code != 404 {
+		t.Errorf("expected code 404, got %d", apiErr.Code)
+	}
+	if secondCalls.Load() != 0 {
+		t.Errorf("expected no failover on 404, got %d calls", secondCalls.Load())
+	}
+}
+
+func TestMultiClient_PostNoFailover(t *testing.T) {
+	var failingCalls, healthyCalls atomic.Int32
+	failing := newTestNode(t, http.StatusOK, "0", http.StatusServiceUnavailable, &failingCalls)
+	healthy := newTestNode(t, http.StatusOK, "0", http.StatusOK, &healthyCalls)
+
+	client, err := NewMultiClient([]string{failing.URL, healthy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the failing node may have gossiped the exit before failing, so it is not sent again
+	err = client.SubmitPoolVoluntaryExit(context.Background(), &phase0.SignedVoluntaryExit{})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected the error of the first node, got %v", err)
+	}
+	if healthyCalls.Load() != 0 {
+		t.Errorf("expected no failover of a POST request, got %d calls", healthyCalls.Load())
+	}
+	if nodes := client.Nodes(); nodes[0].BaseURL != healthy.URL {
+		t.Errorf("expected failing node to be demoted, got %+v", nodes)
+	}
+}
+
+func TestMultiClient_PostQueryFailover(t *testing.T) {
+	var failingCalls atomic.Int32
+	failing := newTestNode(t, http.StatusOK, "0", http.StatusServiceUnavailable, &failingCalls)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v1/beacon/states/head/validators" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"execution_optimistic": false, "finalized": false, "data": []}`))
+	}))
+	t.Cleanup(healthy.Close)
+
+	client, err := NewMultiClient([]string{failing.URL, healthy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// more ids than fit into a URL are queried with POST, which is read-only and fails over
+	ids := make([]string, maxValidatorIDsInQuery+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	if _, err := client.GetValidators(context.Background(), "head", ids, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failingCalls.Load() != 1 {
+		t.Errorf("expected 1 call to the failing node, got %d", failingCalls.Load())
+	}
+	if nodes := client.Nodes(); nodes[0].BaseURL != healthy.URL {
+		t.Errorf("expected failing node to be demoted, got %+v", nodes)
+	}
+}
+
+func TestMultiClient_RecoveredNode(t *testing.T) {
+	var status [2]atomic.Int32
+	servers := make([]*httptest.Server, len(status))
+	for i := range servers {
+		status[i].Store(http.StatusOK)
+		servers[i] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			code := int(status[i].Load())
+			w.WriteHeader(code)
+			if code == http.StatusOK {
+				_, _ = w.Write([]byte(`{"data": {"version": "` + r.Host + `"}}`))
+			}
+		}))
+		t.Cleanup(servers[i].Close)
+	}
+
+	client, err := NewMultiClient([]string{servers[0].URL, servers[1].URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the first node fails and is demoted
+	status[0].Store(http.StatusInternalServerError)
+	if _, err := client.GetNodeVersion(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the second node fails as well, the first node answers again and is restored
+	status[0].Store(http.StatusOK)
+	status[1].Store(http.StatusInternalServerError)
+	if _, err := client.GetNodeVersion(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	nodes := client.Nodes()
+	if nodes[0].BaseURL != servers[0].URL || nodes[0].Err != nil {
+		t.Errorf("expected the recovered node to be ranked first without error, got %+v", nodes)
+	}
+	if nodes[1].Err == nil {
+		t.Errorf("expected the failing node to keep its error, got %+v", nodes)
+	}
+}
+
+func TestMultiClient_RunDefaultInterval(t *testing.T) {
+	var calls atomic.Int32
+	node := newTestNode(t, http.StatusOK, "0", http.StatusOK, &calls)
+
+	client, err := NewMultiClient([]string{node.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a zero interval must not panic, Run refreshes once and returns on cancel
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Run(ctx, 0)
+}
@@ -253,9 +253,11 @@ const (
 // Endpoint: GET /eth/v1/node/health
 // Returns the health status code (200 = ready, 206 = syncing, 503 = not initialized)
 func (c *Client) GetHealth(ctx context.Context) (HealthStatus, error) {
-	fullURL := c.baseURL + "/eth/v1/node/health"
+	if c.pool != nil {
+		return c.pool.best().GetHealth(ctx)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, "/eth/v1/node/health", nil, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,342 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+)
+
+// usesElectraAttestations reports whether a consensus version uses the Electra (EIP-7549) attestation containers
+func usesElectraAttestations(version ConsensusVersion) (bool, error) {
+	switch version {
+	case ConsensusVersionPhase0, ConsensusVersionAltair, ConsensusVersionBellatrix, ConsensusVersionCapella, ConsensusVersionDeneb:
+		return false, nil
+	case ConsensusVersionElectra, ConsensusVersionFulu:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported consensus version: %s", version)
+	}
+}
+
+// VersionedAttesterSlashing is an attester slashing of any fork
+// Phase0 is set up to Deneb, Electra is set from Electra on
+type VersionedAttesterSlashing struct {
+	Version ConsensusVersion
+	Phase0  *phase0.AttesterSlashing
+	Electra *electra.AttesterSlashing
+}
+
+// IntersectingIndices returns the sorted indices of the validators that signed both attestations
+// These are the validators the slashing applies to, unless they are already slashed or withdrawable.
+func (s *VersionedAttesterSlashing) IntersectingIndices() []uint64 {
+	var indices1, indices2 []zrntcommon.ValidatorIndex
+	switch {
+	case s.Phase0 != nil:
+		indices1, indices2 = s.Phase0.Attestation1.AttestingIndices, s.Phase0.Attestation2.AttestingIndices
+	case s.Electra != nil:
+		indices1, indices2 = s.Electra.Attestation1.AttestingIndices, s.Electra.Attestation2.AttestingIndices
+	default:
+		return nil
+	}
+
+	signed := make(map[zrntcommon.ValidatorIndex]struct{}, len(indices1))
+	for _, index := range indices1 {
+		signed[index] = struct{}{}
+	}
+	var indices []uint64
+	for _, index := range indices2 {
+		if _, ok := signed[index]; ok {
+			indices = append(indices, uint64(index))
+			delete(signed, index)
+		}
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// PoolAttestationsResponse represents the response from /eth/v2/beacon/pool/attestations
+type PoolAttestationsResponse struct {
+	Version ConsensusVersion
+	Data    []VersionedAttestation
+}
+
+// GetPoolAttestations retrieves the attestations known by the node but not yet included in a block
+// Endpoint: GET /eth/v2/beacon/pool/attestations
+//
+// nil filters are omitted. Pre-Electra attestations are decoded into Phase0, later ones into Electra.
+func (c *Client) GetPoolAttestations(ctx context.Context, slot, committeeIndex *uint64) (*PoolAttestationsResponse, error) {
+	query := url.Values{}
+	if slot != nil {
+		query.Set("slot", strconv.FormatUint(*slot, 10))
+	}
+	if committeeIndex != nil {
+		query.Set("committee_index", strconv.FormatUint(*committeeIndex, 10))
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v2/beacon/pool/attestations", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Version ConsensusVersion `json:"version"`
+		Data    json.RawMessage  `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	electraAttestations, err := usesElectraAttestations(resp.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	var attestations []VersionedAttestation
+	if electraAttestations {
+		var data []electra.Attestation
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return nil, err
+		}
+		attestations = make([]VersionedAttestation, len(data))
+		for i := range data {
+			attestations[i] = VersionedAttestation{Version: resp.Version, Electra: &data[i]}
+		}
+	} else {
+		var data []phase0.Attestation
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return nil, err
+		}
+		attestations = make([]VersionedAttestation, len(data))
+		for i := range data {
+			attestations[i] = VersionedAttestation{Version: resp.Version, Phase0: &data[i]}
+		}
+	}
+	return &PoolAttestationsResponse{Version: resp.Version, Data: attestations}, nil
+}
+
+// PoolAttesterSlashingsResponse represents the response from /eth/v2/beacon/pool/attester_slashings
+type PoolAttesterSlashingsResponse struct {
+	Version ConsensusVersion
+	Data    []VersionedAttesterSlashing
+}
+
+// GetPoolAttesterSlashings retrieves the attester slashings known by the node but not yet included in a block
+// Endpoint: GET /eth/v2/beacon/pool/attester_slashings
+//
+// Pre-Electra slashings are decoded into Phase0, later ones into Electra.
+func (c *Client) GetPoolAttesterSlashings(ctx context.Context) (*PoolAttesterSlashingsResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v2/beacon/pool/attester_slashings", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Version ConsensusVersion `json:"version"`
+		Data    json.RawMessage  `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	electraSlashings, err := usesElectraAttestations(resp.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	var slashings []VersionedAttesterSlashing
+	if electraSlashings {
+		var data []electra.AttesterSlashing
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return nil, err
+		}
+		slashings = make([]VersionedAttesterSlashing, len(data))
+		for i := range data {
+			slashings[i] = VersionedAttesterSlashing{Version: resp.Version, Electra: &data[i]}
+		}
+	} else {
+		var data []phase0.AttesterSlashing
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return nil, err
+		}
+		slashings = make([]VersionedAttesterSlashing, len(data))
+		for i := range data {
+			slashings[i] = VersionedAttesterSlashing{Version: resp.Version, Phase0: &data[i]}
+		}
+	}
+	return &PoolAttesterSlashingsResponse{Version: resp.Version, Data: slashings}, nil
+}
+
+// PoolProposerSlashingsResponse represents the response from /eth/v1/beacon/pool/proposer_slashings
+type PoolProposerSlashingsResponse struct {
+	Data []phase0.ProposerSlashing `json:"data"`
+}
+
+// GetPoolProposerSlashings retrieves the proposer slashings known by the node but not yet included in a block
+// Endpoint: GET /eth/v1/beacon/pool/proposer_slashings
+func (c *Client) GetPoolProposerSlashings(ctx context.Context) (*PoolProposerSlashingsResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/pool/proposer_slashings", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PoolProposerSlashingsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PoolVoluntaryExitsResponse represents the response from /eth/v1/beacon/pool/voluntary_exits
+type PoolVoluntaryExitsResponse struct {
+	Data []phase0.SignedVoluntaryExit `json:"data"`
+}
+
+// GetPoolVoluntaryExits retrieves the voluntary exits known by the node but not yet included in a block
+// Endpoint: GET /eth/v1/beacon/pool/voluntary_exits
+func (c *Client) GetPoolVoluntaryExits(ctx context.Context) (*PoolVoluntaryExitsResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/pool/voluntary_exits", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PoolVoluntaryExitsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PoolBLSToExecutionChangesResponse represents the response from /eth/v1/beacon/pool/bls_to_execution_changes
+type PoolBLSToExecutionChangesResponse struct {
+	Data []zrntcommon.SignedBLSToExecutionChange `json:"data"`
+}
+
+// GetPoolBLSToExecutionChanges retrieves the BLS to execution changes known by the node but not yet included in a block
+// Endpoint: GET /eth/v1/beacon/pool/bls_to_execution_changes
+func (c *Client) GetPoolBLSToExecutionChanges(ctx context.Context) (*PoolBLSToExecutionChangesResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/pool/bls_to_execution_changes", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PoolBLSToExecutionChangesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SubmitPoolAttestations submits pre-Electra attestations to the node's pool and gossips them
+// Endpoint: POST /eth/v2/beacon/pool/attestations
+//
+// version is the fork of the attestations' slot. Since Electra, use SubmitPoolSingleAttestations.
+// If only some attestations are rejected, the returned error is an *IndexedErrorMessage.
+func (c *Client) SubmitPoolAttestations(ctx context.Context, version ConsensusVersion, attestations []phase0.Attestation) error {
+	electraAttestations, err := usesElectraAttestations(version)
+	if err != nil {
+		return err
+	}
+	if electraAttestations {
+		return fmt.Errorf("%s requires single attestations", version)
+	}
+	if attestations == nil {
+		attestations = []phase0.Attestation{}
+	}
+	_, err = c.doVersionedJSONRequest(ctx, http.MethodPost, "/eth/v2/beacon/pool/attestations", nil, version, attestations)
+	return err
+}
+
+// SubmitPoolSingleAttestations submits Electra single attestations to the node's pool and gossips them
+// Endpoint: POST /eth/v2/beacon/pool/attestations
+//
+// version is the fork of the attestations' slot. Before Electra, use SubmitPoolAttestations.
+// If only some attestations are rejected, the returned error is an *IndexedErrorMessage.
+func (c *Client) SubmitPoolSingleAttestations(ctx context.Context, version ConsensusVersion, attestations []electra.SingleAttestation) error {
+	electraAttestations, err := usesElectraAttestations(version)
+	if err != nil {
+		return err
+	}
+	if !electraAttestations {
+		return fmt.Errorf("%s does not support single attestations", version)
+	}
+	if attestations == nil {
+		attestations = []electra.SingleAttestation{}
+	}
+	_, err = c.doVersionedJSONRequest(ctx, http.MethodPost, "/eth/v2/beacon/pool/attestations", nil, version, attestations)
+	return err
+}
+
+// SubmitPoolAttesterSlashing submits an attester slashing to the node's pool and gossips it
+// Endpoint: POST /eth/v2/beacon/pool/attester_slashings
+func (c *Client) SubmitPoolAttesterSlashing(ctx context.Context, slashing *VersionedAttesterSlashing) error {
+	if slashing == nil {
+		return fmt.Errorf("missing attester slashing")
+	}
+	electraSlashing, err := usesElectraAttestations(slashing.Version)
+	if err != nil {
+		return err
+	}
+
+	var payload any
+	switch {
+	case electraSlashing && slashing.Electra != nil:
+		payload = slashing.Electra
+	case !electraSlashing && slashing.Phase0 != nil:
+		payload = slashing.Phase0
+	default:
+		return fmt.Errorf("missing %s attester slashing", slashing.Version)
+	}
+	_, err = c.doVersionedJSONRequest(ctx, http.MethodPost, "/eth/v2/beacon/pool/attester_slashings", nil, slashing.Version, payload)
+	return err
+}
+
+// SubmitPoolProposerSlashing submits a proposer slashing to the node's pool and gossips it
+// Endpoint: POST /eth/v1/beacon/pool/proposer_slashings
+func (c *Client) SubmitPoolProposerSlashing(ctx context.Context, slashing *phase0.ProposerSlashing) error {
+	if slashing == nil {
+		return fmt.Errorf("missing proposer slashing")
+	}
+	_, err := c.doJSONRequest(ctx, http.MethodPost, "/eth/v1/beacon/pool/proposer_slashings", nil, slashing)
+	return err
+}
+
+// SubmitPoolVoluntaryExit submits a voluntary exit to the node's pool and gossips it
+// Endpoint: POST /eth/v1/beacon/pool/voluntary_exits
+func (c *Client) SubmitPoolVoluntaryExit(ctx context.Context, exit *phase0.SignedVoluntaryExit) error {
+	if exit == nil {
+		return fmt.Errorf("missing voluntary exit")
+	}
+	_, err := c.doJSONRequest(ctx, http.MethodPost, "/eth/v1/beacon/pool/voluntary_exits", nil, exit)
+	return err
+}
+
+// SubmitPoolBLSToExecutionChanges submits BLS to execution changes to the node's pool and gossips them
+// Endpoint: POST /eth/v1/beacon/pool/bls_to_execution_changes
+//
+// If only some changes are rejected, the returned error is an *IndexedErrorMessage.
+func (c *Client) SubmitPoolBLSToExecutionChanges(ctx context.Context, changes []zrntcommon.SignedBLSToExecutionChange) error {
+	if changes == nil {
+		changes = []zrntcommon.SignedBLSToExecutionChange{}
+	}
+	_, err := c.doJSONRequest(ctx, http.MethodPost, "/eth/v1/beacon/pool/bls_to_execution_changes", nil, changes)
+	return err
+}
+
+// SubmitPoolSyncCommitteeMessages submits sync committee messages to the node's pool and gossips them
+// Endpoint: POST /eth/v1/beacon/pool/sync_committees
+//
+// If only some messages are rejected, the returned error is an *IndexedErrorMessage.
+func (c *Client) SubmitPoolSyncCommitteeMessages(ctx context.Context, messages []altair.SyncCommitteeMessage) error {
+	if messages == nil {
+		messages = []altair.SyncCommitteeMessage{}
+	}
+	_, err := c.doJSONRequest(ctx, http.MethodPost, "/eth/v1/beacon/pool/sync_committees", nil, messages)
+	return err
+}
@@ -0,0 +1,406 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+)
+
+const testAttestationDataJSON = `{"slot":"1", "index":"0", "beacon_block_root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2", "source":{"epoch":"1", "root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"}, "target":{"epoch":"1", "root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"}}`
+
+const testSignatureJSON = `"0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"`
+
+func TestGetPoolAttestations(t *testing.T) {
+	tests := []struct {
+		name    string
+		version ConsensusVersion
+		data    string
+		electra bool
+	}{
+		{
+			name:    "deneb",
+			version: ConsensusVersionDeneb,
+			data:    `{"aggregation_bits":"0x03", "data":` + testAttestationDataJSON + `, "signature":` + testSignatureJSON + `}`,
+		},
+		{
+			name:    "electra",
+			version: ConsensusVersionElectra,
+			data:    `{"aggregation_bits":"0x03", "data":` + testAttestationDataJSON + `, "signature":` + testSignatureJSON + `, "committee_bits":"0x0400000000000000"}`,
+			electra: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/eth/v2/beacon/pool/attestations" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				if r.URL.Query().Get("slot") != "1" || r.URL.Query().Get("committee_index") != "2" {
+					t.Errorf("unexpected query: %s", r.URL.RawQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"version":"` + string(tt.version) + `","data":[` + tt.data + `]}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			slot, committeeIndex := uint64(1), uint64(2)
+			resp, err := client.GetPoolAttestations(context.Background(), &slot, &committeeIndex)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Version != tt.version || len(resp.Data) != 1 {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+
+			attestation := resp.Data[0]
+			if attestation.Version != tt.version || (attestation.Electra != nil) != tt.electra || (attestation.Phase0 != nil) == tt.electra {
+				t.Errorf("unexpected attestation: %+v", attestation)
+			}
+			if attestation.Data().Slot != 1 {
+				t.Errorf("expected slot 1, got %d", attestation.Data().Slot)
+			}
+			if tt.electra && !reflect.DeepEqual(attestation.CommitteeIndices(), []uint64{2}) {
+				t.Errorf("expected committee index 2, got %v", attestation.CommitteeIndices())
+			}
+		})
+	}
+}
+
+func TestGetPoolAttestations_NoFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version":"fulu","data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetPoolAttestations(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 0 {
+		t.Errorf("expected no attestations, got %d", len(resp.Data))
+	}
+}
+
+func TestGetPoolAttestations_UnsupportedVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version":"gloas","data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.GetPoolAttestations(context.Background(), nil, nil); err == nil {
+		t.Fatal("expected error for unsupported version")
+	}
+}
+
+func TestGetPoolAttesterSlashings(t *testing.T) {
+	indexedAttestation := func(indices string) string {
+		return `{"attesting_indices":[` + indices + `], "data":` + testAttestationDataJSON + `, "signature":` + testSignatureJSON + `}`
+	}
+	slashing := `{"attestation_1":` + indexedAttestation(`"3","1","7"`) + `, "attestation_2":` + indexedAttestation(`"7","2","3"`) + `}`
+
+	for _, version := range []ConsensusVersion{ConsensusVersionCapella, ConsensusVersionElectra} {
+		t.Run(string(version), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/eth/v2/beacon/pool/attester_slashings" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"version":"` + string(version) + `","data":[` + slashing + `]}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			resp, err := client.GetPoolAttesterSlashings(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Version != version || len(resp.Data) != 1 {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+			if electra := version == ConsensusVersionElectra; (resp.Data[0].Electra != nil) != electra {
+				t.Errorf("unexpected attester slashing: %+v", resp.Data[0])
+			}
+			if indices := resp.Data[0].IntersectingIndices(); !reflect.DeepEqual(indices, []uint64{3, 7}) {
+				t.Errorf("expected intersecting indices [3 7], got %v", indices)
+			}
+		})
+	}
+}
+
+func TestGetPoolProposerSlashings(t *testing.T) {
+	header := func(root string) string {
+		return `{"message":{"slot":"10", "proposer_index":"5", "parent_root":"` + root + `", "state_root":"` + root + `", "body_root":"` + root + `"}, "signature":` + testSignatureJSON + `}`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/pool/proposer_slashings" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"signed_header_1":` + header(testGenesisValidatorsRoot) + `, "signed_header_2":` + header(common.Hash{}.Hex()) + `}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetPoolProposerSlashings(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 {
+		t.Fatalf("expected 1 proposer slashing, got %d", len(resp.Data))
+	}
+	slashing := resp.Data[0]
+	if slashing.SignedHeader1.Message.ProposerIndex != 5 || slashing.SignedHeader1.Message.StateRoot == slashing.SignedHeader2.Message.StateRoot {
+		t.Errorf("unexpected proposer slashing: %+v", slashing)
+	}
+}
+
+func TestGetPoolVoluntaryExits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/pool/voluntary_exits" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"message":{"epoch":"194048", "validator_index":"1"}, "signature":` + testSignatureJSON + `}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetPoolVoluntaryExits(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Message.Epoch != 194048 || resp.Data[0].Message.ValidatorIndex != 1 {
+		t.Errorf("unexpected voluntary exits: %+v", resp.Data)
+	}
+}
+
+func TestGetPoolBLSToExecutionChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/pool/bls_to_execution_changes" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"message":{"validator_index":"1", "from_bls_pubkey":"0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a", "to_execution_address":"0xabcf8e0d4e9587369b2301d0790347320302cc09"}, "signature":` + testSignatureJSON + `}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetPoolBLSToExecutionChanges(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].BLSToExecutionChange.ValidatorIndex != 1 {
+		t.Errorf("unexpected BLS to execution changes: %+v", resp.Data)
+	}
+}
+
+func TestSubmitPoolAttestations(t *testing.T) {
+	var gotVersion string
+	var gotBody []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v2/beacon/pool/attestations" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected Content-Type header: %s", r.Header.Get("Content-Type"))
+		}
+		gotVersion = r.Header.Get("Eth-Consensus-Version")
+		gotBody = nil
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+
+	var attestation phase0.Attestation
+	if err := json.Unmarshal([]byte(`{"aggregation_bits":"0x03", "data":`+testAttestationDataJSON+`, "signature":`+testSignatureJSON+`}`), &attestation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.SubmitPoolAttestations(ctx, ConsensusVersionDeneb, []phase0.Attestation{attestation}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotVersion != "deneb" || len(gotBody) != 1 || gotBody[0]["aggregation_bits"] != "0x03" {
+		t.Errorf("unexpected request: version %q, body %v", gotVersion, gotBody)
+	}
+
+	single := electra.SingleAttestation{CommitteeIndex: 2, AttesterIndex: 7, Data: attestation.Data}
+	if err := client.SubmitPoolSingleAttestations(ctx, ConsensusVersionFulu, []electra.SingleAttestation{single}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotVersion != "fulu" || len(gotBody) != 1 || gotBody[0]["attester_index"] != "7" {
+		t.Errorf("unexpected request: version %q, body %v", gotVersion, gotBody)
+	}
+
+	if err := client.SubmitPoolAttestations(ctx, ConsensusVersionElectra, nil); err == nil {
+		t.Error("expected error for electra attestations")
+	}
+	if err := client.SubmitPoolSingleAttestations(ctx, ConsensusVersionDeneb, nil); err == nil {
+		t.Error("expected error for deneb single attestations")
+	}
+}
+
+func TestSubmitPoolAttesterSlashing(t *testing.T) {
+	var gotVersion string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v2/beacon/pool/attester_slashings" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		gotVersion = r.Header.Get("Eth-Consensus-Version")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	if err := client.SubmitPoolAttesterSlashing(ctx, &VersionedAttesterSlashing{Version: ConsensusVersionElectra, Electra: &electra.AttesterSlashing{}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotVersion != "electra" {
+		t.Errorf("unexpected Eth-Consensus-Version header: %s", gotVersion)
+	}
+
+	if err := client.SubmitPoolAttesterSlashing(ctx, &VersionedAttesterSlashing{Version: ConsensusVersionElectra, Phase0: &phase0.AttesterSlashing{}}); err == nil {
+		t.Error("expected error for a phase0 slashing with electra version")
+	}
+	if err := client.SubmitPoolAttesterSlashing(ctx, nil); err == nil {
+		t.Error("expected error for a nil slashing")
+	}
+}
+
+func TestSubmitPoolOperations_Nil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	if err := client.SubmitPoolProposerSlashing(ctx, nil); err == nil {
+		t.Error("expected error for a nil proposer slashing")
+	}
+	if err := client.SubmitPoolVoluntaryExit(ctx, nil); err == nil {
+		t.Error("expected error for a nil voluntary exit")
+	}
+}
+
+func TestSubmitPoolOperations(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.Header.Get("Eth-Consensus-Version") != "" {
+			t.Errorf("unexpected Eth-Consensus-Version header on %s", r.URL.Path)
+		}
+		requests[r.URL.Path]++
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	if err := client.SubmitPoolProposerSlashing(ctx, &phase0.ProposerSlashing{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := client.SubmitPoolVoluntaryExit(ctx, &phase0.SignedVoluntaryExit{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := client.SubmitPoolBLSToExecutionChanges(ctx, []zrntcommon.SignedBLSToExecutionChange{{}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := client.SubmitPoolSyncCommitteeMessages(ctx, []altair.SyncCommitteeMessage{{}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, path := range []string{
+		"/eth/v1/beacon/pool/proposer_slashings",
+		"/eth/v1/beacon/pool/voluntary_exits",
+		"/eth/v1/beacon/pool/bls_to_execution_changes",
+		"/eth/v1/beacon/pool/sync_committees",
+	} {
+		if requests[path] != 1 {
+			t.Errorf("expected 1 request to %s, got %d", path, requests[path])
+		}
+	}
+}
+
+func TestSubmitPoolOperations_EmptyBatch(t *testing.T) {
+	bodies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read body: %v", err)
+		}
+		bodies[r.URL.Path] = string(body)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	if err := client.SubmitPoolAttestations(ctx, ConsensusVersionDeneb, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if bodies["/eth/v2/beacon/pool/attestations"] != "[]" {
+		t.Errorf("unexpected attestations body: %s", bodies["/eth/v2/beacon/pool/attestations"])
+	}
+	if err := client.SubmitPoolSingleAttestations(ctx, ConsensusVersionElectra, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if bodies["/eth/v2/beacon/pool/attestations"] != "[]" {
+		t.Errorf("unexpected single attestations body: %s", bodies["/eth/v2/beacon/pool/attestations"])
+	}
+	if err := client.SubmitPoolBLSToExecutionChanges(ctx, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := client.SubmitPoolSyncCommitteeMessages(ctx, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, path := range []string{
+		"/eth/v1/beacon/pool/bls_to_execution_changes",
+		"/eth/v1/beacon/pool/sync_committees",
+	} {
+		if bodies[path] != "[]" {
+			t.Errorf("expected [] to be sent to %s, got %s", path, bodies[path])
+		}
+	}
+}
+
+func TestSubmitPoolSyncCommitteeMessages_Failures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":400,"message":"some failures","failures":[{"index":1,"message":"invalid signature"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	err := client.SubmitPoolSyncCommitteeMessages(context.Background(), make([]altair.SyncCommitteeMessage, 2))
+	var indexedErr *IndexedErrorMessage
+	if !errors.As(err, &indexedErr) {
+		t.Fatalf("expected *IndexedErrorMessage, got %v", err)
+	}
+	if len(indexedErr.Failures) != 1 || indexedErr.Failures[0].Index != 1 {
+		t.Errorf("unexpected failures: %+v", indexedErr.Failures)
+	}
+}
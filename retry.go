@@ -0,0 +1,107 @@
+package beaconclient
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed requests
+//
+// Only idempotent requests (GET and HEAD, and read-only POST queries) are retried, and only when the request
+// failed on the network level or the node answered with 429, 502, 503 or 504.
+// Other API errors such as 400 or 404 are returned immediately.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one (<= 1 disables retries)
+	MaxAttempts int
+	// InitialBackoff is the base delay before the first retry, doubled after every attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, including delays requested via Retry-After
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable retry policy for long running services
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+// WithRetry enables automatic retries with exponential backoff and jitter
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// shouldRetry reports whether a failed attempt may be retried
+// resp is nil if the request failed before a response was received
+func (p RetryPolicy) shouldRetry(idempotent bool, attempt int, resp *response) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if !idempotent {
+		return false
+	}
+	if resp == nil {
+		return true
+	}
+	switch resp.statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the delay before the next attempt
+// A Retry-After header sent by the node takes precedence over the computed backoff
+func (p RetryPolicy) backoff(attempt int, resp *response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return delay
+		}
+	}
+
+	// double the delay until it reaches MaxBackoff, so that it cannot overflow for late attempts
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay > 0 && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// equal jitter: pick a random delay in [delay/2, delay]
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// parseRetryAfter parses the Retry-After header in either delay-seconds or HTTP-date form
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// isIdempotent reports whether a request of the method may be sent more than once
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
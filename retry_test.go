@@ -0,0 +1,190 @@
+package beaconclient
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+}
+
+func TestDoRequest_RetryOnServiceUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetry(testRetryPolicy))
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/test", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_RetryExhausted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`{"code": 502, "message": "Bad gateway"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetry(testRetryPolicy))
+	_, err := client.doRequest(context.Background(), http.MethodGet, "/test", nil)
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Code != 502 {
+		t.Errorf("expected code 502, got %d", apiErr.Code)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_NoRetryOnNotFound(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 404, "message": "Block not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetry(testRetryPolicy))
+	_, err := client.GetBlock(context.Background(), "999999999")
+	if _, ok := err.(*APIError); !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_RetryOnNetworkError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("failed to hijack connection: %v", err)
+			}
+			_ = conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetry(testRetryPolicy))
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/test", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_NoRetryByDefault(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/test", nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+	tests := []struct {
+		name       string
+		idempotent bool
+		attempt    int
+		status     int // 0 means network error
+		want       bool
+	}{
+		{"network error", true, 1, 0, true},
+		{"too many requests", true, 1, http.StatusTooManyRequests, true},
+		{"bad gateway", true, 2, http.StatusBadGateway, true},
+		{"attempts exhausted", true, 3, http.StatusServiceUnavailable, false},
+		{"not found", true, 1, http.StatusNotFound, false},
+		{"bad request", true, 1, http.StatusBadRequest, false},
+		{"non idempotent", false, 1, http.StatusServiceUnavailable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *response
+			if tt.status != 0 {
+				resp = &response{statusCode: tt.status}
+			}
+			if got := policy.shouldRetry(tt.idempotent, tt.attempt, resp); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt := 1; attempt < 10; attempt++ {
+		want := min(100*time.Millisecond<<(attempt-1), time.Second)
+		got := policy.backoff(attempt, nil)
+		if got < want/2 || got > want {
+			t.Errorf("attempt %d: backoff %s not in [%s, %s]", attempt, got, want/2, want)
+		}
+	}
+
+	// the doubling stops at MaxBackoff instead of overflowing
+	for _, attempt := range []int{40, 64, 100, math.MaxInt} {
+		if got := policy.backoff(attempt, nil); got < time.Second/2 || got > time.Second {
+			t.Errorf("attempt %d: backoff %s not in [%s, %s]", attempt, got, time.Second/2, time.Second)
+		}
+	}
+	uncapped := RetryPolicy{InitialBackoff: 100 * time.Millisecond}
+	if got := uncapped.backoff(100, nil); got < math.MaxInt64/2 {
+		t.Errorf("expected the uncapped backoff to saturate, got %s", got)
+	}
+
+	resp := &response{header: http.Header{"Retry-After": []string{"2"}}}
+	if got := policy.backoff(1, resp); got != time.Second {
+		t.Errorf("expected Retry-After to be capped at 1s, got %s", got)
+	}
+
+	resp.header.Set("Retry-After", "0")
+	if got := policy.backoff(1, resp); got != 0 {
+		t.Errorf("expected Retry-After 0, got %s", got)
+	}
+}
@@ -0,0 +1,145 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// BlockRewards contains the rewards of the proposer of a block in Gwei
+type BlockRewards struct {
+	// ProposerIndex is the index of the proposer of the block
+	ProposerIndex uint64 `json:"proposer_index,string"`
+	// Total is the total block reward
+	Total uint64 `json:"total,string"`
+	// Attestations is the reward for included attestations
+	Attestations uint64 `json:"attestations,string"`
+	// SyncAggregate is the reward for the included sync aggregate
+	SyncAggregate uint64 `json:"sync_aggregate,string"`
+	// ProposerSlashings is the reward for included proposer slashings
+	ProposerSlashings uint64 `json:"proposer_slashings,string"`
+	// AttesterSlashings is the reward for included attester slashings
+	AttesterSlashings uint64 `json:"attester_slashings,string"`
+}
+
+// BlockRewardsResponse represents the response from /eth/v1/beacon/rewards/blocks/{block_id}
+type BlockRewardsResponse struct {
+	ExecutionOptimistic bool         `json:"execution_optimistic"`
+	Finalized           bool         `json:"finalized"`
+	Data                BlockRewards `json:"data"`
+}
+
+// GetBlockRewards retrieves the rewards the proposer of a block received for it
+// Endpoint: GET /eth/v1/beacon/rewards/blocks/{block_id}
+//
+// block_id can be: "head", "genesis", "finalized", <slot>, <hex encoded blockRoot with 0x prefix>
+func (c *Client) GetBlockRewards(ctx context.Context, blockID string) (*BlockRewardsResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/rewards/blocks/"+blockID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp BlockRewardsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// IdealAttestationRewards contains the rewards in Gwei a validator with the given
+// effective balance would have received for perfect attestations
+type IdealAttestationRewards struct {
+	EffectiveBalance uint64 `json:"effective_balance,string"`
+	Head             int64  `json:"head,string"`
+	Target           int64  `json:"target,string"`
+	Source           int64  `json:"source,string"`
+	// InclusionDelay is only present for phase0 epochs
+	InclusionDelay *uint64 `json:"inclusion_delay,string,omitempty"`
+	Inactivity     int64   `json:"inactivity,string"`
+}
+
+// AttestationRewards contains the attestation rewards of a validator in Gwei
+// Negative values are penalties
+type AttestationRewards struct {
+	ValidatorIndex uint64 `json:"validator_index,string"`
+	Head           int64  `json:"head,string"`
+	Target         int64  `json:"target,string"`
+	Source         int64  `json:"source,string"`
+	// InclusionDelay is only present for phase0 epochs
+	InclusionDelay *uint64 `json:"inclusion_delay,string,omitempty"`
+	Inactivity     int64   `json:"inactivity,string"`
+}
+
+// AttestationRewardsData contains the ideal and the actual attestation rewards of an epoch
+type AttestationRewardsData struct {
+	IdealRewards []IdealAttestationRewards `json:"ideal_rewards"`
+	TotalRewards []AttestationRewards      `json:"total_rewards"`
+}
+
+// AttestationRewardsResponse represents the response from /eth/v1/beacon/rewards/attestations/{epoch}
+type AttestationRewardsResponse struct {
+	ExecutionOptimistic bool                   `json:"execution_optimistic"`
+	Finalized           bool                   `json:"finalized"`
+	Data                AttestationRewardsData `json:"data"`
+}
+
+// GetAttestationRewards retrieves the attestation rewards of validators for the given epoch
+// Endpoint: POST /eth/v1/beacon/rewards/attestations/{epoch}
+//
+// validatorIDs can be validator indices or hex encoded public keys with 0x prefix,
+// rewards of all validators are returned if empty
+func (c *Client) GetAttestationRewards(ctx context.Context, epoch uint64, validatorIDs []string) (*AttestationRewardsResponse, error) {
+	if validatorIDs == nil {
+		validatorIDs = []string{}
+	}
+
+	endpoint := "/eth/v1/beacon/rewards/attestations/" + strconv.FormatUint(epoch, 10)
+	body, err := c.doQueryJSONRequest(ctx, endpoint, nil, validatorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp AttestationRewardsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SyncCommitteeReward contains the sync committee reward of a validator in Gwei
+// Negative values are penalties
+type SyncCommitteeReward struct {
+	ValidatorIndex uint64 `json:"validator_index,string"`
+	Reward         int64  `json:"reward,string"`
+}
+
+// SyncCommitteeRewardsResponse represents the response from /eth/v1/beacon/rewards/sync_committee/{block_id}
+type SyncCommitteeRewardsResponse struct {
+	ExecutionOptimistic bool                  `json:"execution_optimistic"`
+	Finalized           bool                  `json:"finalized"`
+	Data                []SyncCommitteeReward `json:"data"`
+}
+
+// GetSyncCommitteeRewards retrieves the sync committee rewards of validators for the given block
+// Endpoint: POST /eth/v1/beacon/rewards/sync_committee/{block_id}
+//
+// block_id can be: "head", "genesis", "finalized", <slot>, <hex encoded blockRoot with 0x prefix>
+// validatorIDs can be validator indices or hex encoded public keys with 0x prefix,
+// rewards of all sync committee members are returned if empty
+func (c *Client) GetSyncCommitteeRewards(ctx context.Context, blockID string, validatorIDs []string) (*SyncCommitteeRewardsResponse, error) {
+	if validatorIDs == nil {
+		validatorIDs = []string{}
+	}
+
+	body, err := c.doQueryJSONRequest(ctx, "/eth/v1/beacon/rewards/sync_committee/"+blockID, nil, validatorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SyncCommitteeRewardsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,152 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetBlockRewards_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/rewards/blocks/head" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": false,
+			"data": {
+				"proposer_index": "123",
+				"total": "123",
+				"attestations": "111",
+				"sync_aggregate": "12",
+				"proposer_slashings": "0",
+				"attester_slashings": "0"
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetBlockRewards(context.Background(), "head")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Data.ProposerIndex != 123 {
+		t.Errorf("expected proposer_index 123, got %d", resp.Data.ProposerIndex)
+	}
+	if resp.Data.Total != 123 {
+		t.Errorf("expected total 123, got %d", resp.Data.Total)
+	}
+	if resp.Data.Attestations != 111 || resp.Data.SyncAggregate != 12 {
+		t.Errorf("unexpected rewards: %+v", resp.Data)
+	}
+}
+
+func TestGetAttestationRewards_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/rewards/attestations/1000" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+
+		var ids []string
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if len(ids) != 2 || ids[0] != "1" {
+			t.Errorf("unexpected validator ids: %v", ids)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": true,
+			"data": {
+				"ideal_rewards": [
+					{"effective_balance": "32000000000", "head": "2500", "target": "5000", "source": "5000", "inactivity": "0"}
+				],
+				"total_rewards": [
+					{"validator_index": "1", "head": "2000", "target": "2000", "source": "4000", "inactivity": "0"},
+					{"validator_index": "2", "head": "0", "target": "-5000", "source": "-2700", "inactivity": "-100"}
+				]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetAttestationRewards(context.Background(), 1000, []string{"1", "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data.IdealRewards) != 1 || resp.Data.IdealRewards[0].EffectiveBalance != 32000000000 {
+		t.Errorf("unexpected ideal rewards: %+v", resp.Data.IdealRewards)
+	}
+	if resp.Data.IdealRewards[0].InclusionDelay != nil {
+		t.Error("expected no inclusion_delay")
+	}
+	if len(resp.Data.TotalRewards) != 2 {
+		t.Fatalf("expected 2 total rewards, got %d", len(resp.Data.TotalRewards))
+	}
+	penalty := resp.Data.TotalRewards[1]
+	if penalty.ValidatorIndex != 2 || penalty.Target != -5000 || penalty.Inactivity != -100 {
+		t.Errorf("unexpected total rewards: %+v", penalty)
+	}
+}
+
+func TestGetSyncCommitteeRewards_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/rewards/sync_committee/finalized" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+
+		var ids []string
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if ids == nil || len(ids) != 0 {
+			t.Errorf("expected empty validator ids, got %v", ids)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": true,
+			"data": [
+				{"validator_index": "1", "reward": "2000"},
+				{"validator_index": "2", "reward": "-2000"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetSyncCommitteeRewards(context.Background(), "finalized", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 rewards, got %d", len(resp.Data))
+	}
+	if resp.Data[1].ValidatorIndex != 2 || resp.Data[1].Reward != -2000 {
+		t.Errorf("unexpected reward: %+v", resp.Data[1])
+	}
+}
@@ -0,0 +1,56 @@
+package beaconclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	blsu "github.com/protolambda/bls12-381-util"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// ErrInvalidSignature is returned when a BLS signature does not verify
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// VerifyProposerSignature verifies the proposer signature of a signed block header
+//
+// The signature is checked against the proposer domain of the fork active at the header's slot,
+// using the fork versions and epochs of the spec and the genesis validators root of genesis.
+// A signed block is verified through its header, which has the same root:
+//
+//	message, err := block.Header(spec)
+//	header := SignedBeaconBlockHeader{Message: *message, Signature: signature}
+func VerifyProposerSignature(spec *Spec, genesis *GenesisData, pubkey zrntcommon.BLSPubkey, header *SignedBeaconBlockHeader) error {
+	if spec == nil {
+		return fmt.Errorf("spec is required to verify signatures")
+	}
+	if genesis == nil {
+		return fmt.Errorf("genesis data is required to verify signatures")
+	}
+	if header == nil {
+		return fmt.Errorf("missing signed block header")
+	}
+
+	epoch := ComputeEpochAtSlot(spec, header.Message.Slot)
+	domain, err := ComputeDomain(spec, genesis, zrntcommon.DOMAIN_BEACON_PROPOSER, epoch)
+	if err != nil {
+		return err
+	}
+	return verifySignature(pubkey, ComputeSigningRoot(header.Message.HashTreeRoot(), domain), header.Signature)
+}
+
+// verifySignature verifies a BLS signature over a signing root
+func verifySignature(pubkey zrntcommon.BLSPubkey, signingRoot common.Hash, signature zrntcommon.BLSSignature) error {
+	pub, err := pubkey.Pubkey()
+	if err != nil {
+		return fmt.Errorf("invalid pubkey: %w", err)
+	}
+	sig, err := signature.Signature()
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if !blsu.Verify(pub, signingRoot[:], sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
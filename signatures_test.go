@@ -0,0 +1,99 @@
+package beaconclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blsu "github.com/protolambda/bls12-381-util"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/configs"
+)
+
+const testGenesisValidatorsRoot = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+
+// newTestSignedHeader signs the header of a testdata block as its proposer would
+func newTestSignedHeader(t *testing.T, spec *Spec, forkVersion zrntcommon.Version) (*SignedBeaconBlockHeader, zrntcommon.BLSPubkey) {
+	t.Helper()
+	var secret [32]byte
+	secret[31] = 42
+	var sk blsu.SecretKey
+	if err := sk.Deserialize(&secret); err != nil {
+		t.Fatalf("failed to create secret key: %v", err)
+	}
+	pub, err := blsu.SkToPk(&sk)
+	if err != nil {
+		t.Fatalf("failed to derive pubkey: %v", err)
+	}
+
+	block := loadVersionedBlock(t, "testdata/deneb.block.json")
+	header := &SignedBeaconBlockHeader{Message: *testBlockHeader(t, spec, block)}
+
+	domain := zrntcommon.ComputeDomain(zrntcommon.DOMAIN_BEACON_PROPOSER, forkVersion, zrntcommon.Root(common.HexToHash(testGenesisValidatorsRoot)))
+	signingRoot := zrntcommon.ComputeSigningRoot(zrntcommon.Root(header.Message.HashTreeRoot()), domain)
+	header.Signature = blsu.Sign(&sk, signingRoot[:]).Serialize()
+	return header, pub.Serialize()
+}
+
+func TestVerifyProposerSignature(t *testing.T) {
+	spec := &Spec{Spec: *configs.Mainnet}
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+	header, pubkey := newTestSignedHeader(t, spec, spec.DENEB_FORK_VERSION)
+
+	if err := VerifyProposerSignature(spec, genesis, pubkey, header); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	forged := *header
+	forged.Message.ProposerIndex++
+	if err := VerifyProposerSignature(spec, genesis, pubkey, &forged); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a forged header, got %v", err)
+	}
+
+	otherGenesis := &GenesisData{GenesisValidatorsRoot: common.Hash{0x01}.Hex()}
+	if err := VerifyProposerSignature(spec, otherGenesis, pubkey, header); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for another chain, got %v", err)
+	}
+}
+
+func TestVerifyProposerSignature_WrongFork(t *testing.T) {
+	spec := &Spec{Spec: *configs.Mainnet}
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+	header, pubkey := newTestSignedHeader(t, spec, spec.CAPELLA_FORK_VERSION)
+
+	if err := VerifyProposerSignature(spec, genesis, pubkey, header); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestVerifyProposerSignature_InvalidInput(t *testing.T) {
+	spec := &Spec{Spec: *configs.Mainnet}
+	header, pubkey := newTestSignedHeader(t, spec, spec.DENEB_FORK_VERSION)
+
+	if err := VerifyProposerSignature(spec, &GenesisData{GenesisValidatorsRoot: "0x1234"}, pubkey, header); err == nil {
+		t.Error("expected error for an invalid genesis validators root")
+	}
+
+	genesis := &GenesisData{GenesisValidatorsRoot: testGenesisValidatorsRoot}
+	if err := VerifyProposerSignature(spec, nil, pubkey, header); err == nil {
+		t.Error("expected error for missing genesis data")
+	}
+	if err := VerifyProposerSignature(spec, genesis, pubkey, nil); err == nil {
+		t.Error("expected error for a missing header")
+	}
+
+	header.Signature = zrntcommon.BLSSignature{0x01}
+	if err := VerifyProposerSignature(spec, genesis, pubkey, header); err == nil || errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected a signature encoding error, got %v", err)
+	}
+}
+
+func TestSignedBeaconBlock_Signature(t *testing.T) {
+	block := loadSignedBlock(t, "testdata/deneb.block.json")
+	if block.Data.Signature == (zrntcommon.BLSSignature{}) {
+		t.Fatal("expected block signature to be decoded")
+	}
+	if _, err := block.Data.Signature.Signature(); err != nil {
+		t.Errorf("expected a valid signature point: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package beaconclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeSource provides the current time and timers to a SlotClock
+type TimeSource interface {
+	// Now returns the current time
+	Now() time.Time
+	// NewTimer creates a timer that sends the current time on its channel after the duration
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer created by a TimeSource
+type Timer interface {
+	// C returns the channel on which the time is delivered when the timer fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, it returns false if the timer already fired or was stopped
+	Stop() bool
+}
+
+// systemTime is the TimeSource backed by the system clock
+type systemTime struct{}
+
+func (systemTime) Now() time.Time {
+	return time.Now()
+}
+
+func (systemTime) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// systemTimer is the Timer backed by a time.Timer
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// SlotClockOption configures a SlotClock
+type SlotClockOption func(*SlotClock)
+
+// WithTimeSource sets the time source of the slot clock, e.g. a fake clock in tests
+func WithTimeSource(source TimeSource) SlotClockOption {
+	return func(c *SlotClock) {
+		c.time = source
+	}
+}
+
+// SlotClock tracks the slots and epochs of the chain from its genesis time
+type SlotClock struct {
+	genesisTime   time.Time
+	slotDuration  time.Duration
+	slotsPerEpoch uint64
+	time          TimeSource
+}
+
+// NewSlotClock creates a slot clock from the genesis data and spec of the chain
+func NewSlotClock(genesis *GenesisData, spec *Spec, opts ...SlotClockOption) (*SlotClock, error) {
+	if genesis == nil || spec == nil {
+		return nil, fmt.Errorf("genesis and spec are required for a slot clock")
+	}
+	if spec.SECONDS_PER_SLOT == 0 || spec.SLOTS_PER_EPOCH == 0 {
+		return nil, fmt.Errorf("invalid spec: SECONDS_PER_SLOT and SLOTS_PER_EPOCH must be set")
+	}
+
+	c := &SlotClock{
+		genesisTime:   time.Unix(int64(genesis.GenesisTime), 0),
+		slotDuration:  time.Duration(spec.SECONDS_PER_SLOT) * time.Second,
+		slotsPerEpoch: uint64(spec.SLOTS_PER_EPOCH),
+		time:          systemTime{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// NewSlotClock creates a slot clock from the genesis data and spec reported by the beacon node
+func (c *Client) NewSlotClock(ctx context.Context, opts ...SlotClockOption) (*SlotClock, error) {
+	genesis, err := c.GetGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := c.GetSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewSlotClock(genesis, spec, opts...)
+}
+
+// CurrentSlot returns the current slot, GENESIS_SLOT before genesis
+func (c *SlotClock) CurrentSlot() uint64 {
+	return c.slotAt(c.time.Now())
+}
+
+// CurrentEpoch returns the current epoch
+func (c *SlotClock) CurrentEpoch() uint64 {
+	return c.CurrentSlot() / c.slotsPerEpoch
+}
+
+// SlotStart returns the start time of a slot
+func (c *SlotClock) SlotStart(slot uint64) time.Time {
+	return c.genesisTime.Add(time.Duration(slot-GENESIS_SLOT) * c.slotDuration)
+}
+
+// NextSlotIn returns the duration until the start of the next slot, or until genesis before genesis
+func (c *SlotClock) NextSlotIn() time.Duration {
+	now := c.time.Now()
+	return c.SlotStart(c.nextSlot(now, 1)).Sub(now)
+}
+
+// SlotTicker returns a channel that receives each slot at its start
+//
+// Ticks are not buffered: a slot the receiver did not take before the next slot started
+// is dropped, so a receiver falling behind receives the current slot instead of stale ones.
+// The channel is closed when the context is canceled.
+func (c *SlotClock) SlotTicker(ctx context.Context) <-chan uint64 {
+	ticks := make(chan uint64)
+	go c.tick(ctx, ticks, 1)
+	return ticks
+}
+
+// EpochTicker returns a channel that receives each epoch at the start of its first slot
+//
+// Ticks are not buffered: an epoch the receiver did not take before the next epoch started
+// is dropped, so a receiver falling behind receives the current epoch instead of stale ones.
+// The channel is closed when the context is canceled.
+func (c *SlotClock) EpochTicker(ctx context.Context) <-chan uint64 {
+	ticks := make(chan uint64)
+	go c.tick(ctx, ticks, c.slotsPerEpoch)
+	return ticks
+}
+
+// tick sends slot / interval to ticks at the start of every slot that is a multiple of interval
+// A tick still waiting for the receiver is replaced by the next one.
+func (c *SlotClock) tick(ctx context.Context, ticks chan<- uint64, interval uint64) {
+	defer close(ticks)
+
+	// pending is set to ticks while value waits for the receiver
+	var pending chan<- uint64
+	var value uint64
+	for {
+		now := c.time.Now()
+		next := c.nextSlot(now, interval)
+		timer := c.time.NewTimer(c.SlotStart(next).Sub(now))
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case pending <- value:
+				pending = nil
+			case <-timer.C():
+				break wait
+			}
+		}
+		value, pending = next/interval, ticks
+	}
+}
+
+// slotAt returns the slot at a given time
+func (c *SlotClock) slotAt(t time.Time) uint64 {
+	if t.Before(c.genesisTime) {
+		return GENESIS_SLOT
+	}
+	return GENESIS_SLOT + uint64(t.Sub(c.genesisTime)/c.slotDuration)
+}
+
+// nextSlot returns the first slot after t that is a multiple of interval, or GENESIS_SLOT before genesis
+func (c *SlotClock) nextSlot(t time.Time, interval uint64) uint64 {
+	if t.Before(c.genesisTime) {
+		return GENESIS_SLOT
+	}
+	return (c.slotAt(t)/interval + 1) * interval
+}
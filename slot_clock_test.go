@@ -0,0 +1,247 @@
+package beaconclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/protolambda/zrnt/eth2/configs"
+)
+
+// fakeTime is a TimeSource that only moves when advanced
+type fakeTime struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// fakeTimer is a Timer of a fakeTime
+type fakeTimer struct {
+	source *fakeTime
+	ch     chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.source.mu.Lock()
+	defer t.source.mu.Unlock()
+	for i, w := range t.source.waiters {
+		if w.ch == t.ch {
+			t.source.waiters = append(t.source.waiters[:i], t.source.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func newFakeTime(now time.Time) *fakeTime {
+	return &fakeTime{now: now}
+}
+
+func (f *fakeTime) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeTime) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	f.waiters = append(f.waiters, fakeWaiter{deadline: f.now.Add(d), ch: ch})
+	return &fakeTimer{source: f, ch: ch}
+}
+
+// pendingTimers returns the number of timers that neither fired nor were stopped
+func (f *fakeTime) pendingTimers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// Advance moves the time forward and fires the expired timers
+func (f *fakeTime) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	pending := f.waiters[:0]
+	for _, w := range f.waiters {
+		if w.deadline.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- f.now
+	}
+	f.waiters = pending
+}
+
+// waitForTimer blocks until a timer has been registered
+func (f *fakeTime) waitForTimer(t *testing.T) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if f.pendingTimers() > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for a timer")
+}
+
+func receiveTick(t *testing.T, ticks <-chan uint64) uint64 {
+	t.Helper()
+	select {
+	case tick := <-ticks:
+		return tick
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a tick")
+		return 0
+	}
+}
+
+func newTestSlotClock(t *testing.T, now time.Time) (*SlotClock, *fakeTime) {
+	t.Helper()
+	source := newFakeTime(now)
+	clock, err := NewSlotClock(&GenesisData{GenesisTime: 1606824023}, &Spec{Spec: *configs.Mainnet}, WithTimeSource(source))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return clock, source
+}
+
+func TestSlotClock_Current(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	clock, source := newTestSlotClock(t, genesis.Add(-30*time.Second))
+
+	if clock.CurrentSlot() != 0 {
+		t.Errorf("expected slot 0 before genesis, got %d", clock.CurrentSlot())
+	}
+	if clock.NextSlotIn() != 30*time.Second {
+		t.Errorf("expected 30s until genesis, got %s", clock.NextSlotIn())
+	}
+
+	source.Advance(30*time.Second + 100*12*time.Second + 5*time.Second)
+	if clock.CurrentSlot() != 100 {
+		t.Errorf("expected slot 100, got %d", clock.CurrentSlot())
+	}
+	if clock.CurrentEpoch() != 3 {
+		t.Errorf("expected epoch 3, got %d", clock.CurrentEpoch())
+	}
+	if clock.NextSlotIn() != 7*time.Second {
+		t.Errorf("expected 7s until next slot, got %s", clock.NextSlotIn())
+	}
+	if want := time.Unix(int64(ComputeTimestampAtSlot(1606824023, 101, 12)), 0); !clock.SlotStart(101).Equal(want) {
+		t.Errorf("expected slot 101 to start at %s, got %s", want, clock.SlotStart(101))
+	}
+}
+
+func TestSlotClock_SlotTicker(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	clock, source := newTestSlotClock(t, genesis.Add(100*12*time.Second+5*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := clock.SlotTicker(ctx)
+
+	for want := uint64(101); want <= 103; want++ {
+		source.waitForTimer(t)
+		source.Advance(clock.NextSlotIn())
+		if got := receiveTick(t, ticks); got != want {
+			t.Errorf("expected slot %d, got %d", want, got)
+		}
+	}
+
+	source.waitForTimer(t)
+	cancel()
+	if _, ok := <-ticks; ok {
+		t.Error("expected ticker to be closed after cancel")
+	}
+	if n := source.pendingTimers(); n != 0 {
+		t.Errorf("expected the timer to be stopped after cancel, got %d pending timers", n)
+	}
+}
+
+func TestSlotClock_SlotTickerDropsStaleTicks(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	clock, source := newTestSlotClock(t, genesis.Add(100*12*time.Second+5*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticks := clock.SlotTicker(ctx)
+
+	// slot 101 is not received before slot 102 starts
+	source.waitForTimer(t)
+	source.Advance(clock.NextSlotIn())
+	source.waitForTimer(t)
+	source.Advance(12 * time.Second)
+	source.waitForTimer(t)
+
+	if got := receiveTick(t, ticks); got != 102 {
+		t.Errorf("expected the current slot 102, got %d", got)
+	}
+}
+
+func TestSlotClock_EpochTicker(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	clock, source := newTestSlotClock(t, genesis.Add(-time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticks := clock.EpochTicker(ctx)
+
+	// the first tick is at genesis
+	source.waitForTimer(t)
+	source.Advance(time.Minute)
+	if got := receiveTick(t, ticks); got != 0 {
+		t.Errorf("expected epoch 0, got %d", got)
+	}
+
+	source.waitForTimer(t)
+	source.Advance(32 * 12 * time.Second)
+	if got := receiveTick(t, ticks); got != 1 {
+		t.Errorf("expected epoch 1, got %d", got)
+	}
+}
+
+func TestNewSlotClock_InvalidSpec(t *testing.T) {
+	if _, err := NewSlotClock(&GenesisData{}, &Spec{}); err == nil {
+		t.Error("expected error for an empty spec")
+	}
+	if _, err := NewSlotClock(nil, &Spec{Spec: *configs.Mainnet}); err == nil {
+		t.Error("expected error for missing genesis")
+	}
+}
+
+func TestClient_NewSlotClock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			_, _ = w.Write([]byte(`{"data": {"genesis_time": "1606824023", "genesis_validators_root": "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95", "genesis_fork_version": "0x00000000"}}`))
+		case "/eth/v1/config/spec":
+			_, _ = w.Write([]byte(`{"data": {"SECONDS_PER_SLOT": "12", "SLOTS_PER_EPOCH": "32"}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	source := newFakeTime(time.Unix(1606824023+12*64, 0))
+	clock, err := NewClient(server.URL).NewSlotClock(context.Background(), WithTimeSource(source))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clock.CurrentSlot() != 64 || clock.CurrentEpoch() != 2 {
+		t.Errorf("unexpected slot %d epoch %d", clock.CurrentSlot(), clock.CurrentEpoch())
+	}
+}
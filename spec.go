@@ -15,8 +15,10 @@ type BlobScheduleEntry struct {
 	MaxBlobsPerBlock view.Uint64View `json:"MAX_BLOBS_PER_BLOCK"`
 }
 
+// Spec contains the presets and configuration reported by the beacon node
+// It embeds the zrnt spec, so &spec.Spec can be used for SSZ decoding and hashing
 type Spec struct {
-	common.Config
+	common.Spec
 	BLOB_SCHEDULE []BlobScheduleEntry `json:"BLOB_SCHEDULE"`
 }
 
@@ -217,6 +217,9 @@ func TestGetSpec_Success(t *testing.T) {
 	if spec.SECONDS_PER_SLOT != 12 {
 		t.Errorf("expected SECONDS_PER_SLOT 12, got %d", spec.SECONDS_PER_SLOT)
 	}
+	if spec.SLOTS_PER_EPOCH != 32 {
+		t.Errorf("expected preset SLOTS_PER_EPOCH 32, got %d", spec.SLOTS_PER_EPOCH)
+	}
 	if spec.DENEB_FORK_EPOCH != 269568 {
 		t.Errorf("expected DENEB_FORK_EPOCH 269568, got %d", spec.DENEB_FORK_EPOCH)
 	}
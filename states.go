@@ -0,0 +1,147 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// StateRootData represents the state root response data
+type StateRootData struct {
+	Root common.Hash `json:"root"`
+}
+
+// StateRootResponse represents the response from /eth/v1/beacon/states/{state_id}/root
+type StateRootResponse struct {
+	ExecutionOptimistic bool          `json:"execution_optimistic"`
+	Finalized           bool          `json:"finalized"`
+	Data                StateRootData `json:"data"`
+}
+
+// GetStateRoot retrieves hashTreeRoot of BeaconState
+// Endpoint: GET /eth/v1/beacon/states/{state_id}/root
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+func (c *Client) GetStateRoot(ctx context.Context, stateID string) (*StateRootResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/states/"+stateID+"/root", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp StateRootResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Fork represents the fork object of a beacon state
+type Fork struct {
+	// PreviousVersion is the fork version before the last fork
+	PreviousVersion zrntcommon.Version `json:"previous_version"`
+	// CurrentVersion is the fork version currently in effect
+	CurrentVersion zrntcommon.Version `json:"current_version"`
+	// Epoch is the epoch of the last fork
+	Epoch uint64 `json:"epoch,string"`
+}
+
+// StateForkResponse represents the response from /eth/v1/beacon/states/{state_id}/fork
+type StateForkResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                Fork `json:"data"`
+}
+
+// GetStateFork retrieves the Fork object for the requested state
+// Endpoint: GET /eth/v1/beacon/states/{state_id}/fork
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+func (c *Client) GetStateFork(ctx context.Context, stateID string) (*StateForkResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/states/"+stateID+"/fork", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp StateForkResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// FinalityCheckpoints contains the justification and finality checkpoints of a beacon state
+type FinalityCheckpoints struct {
+	PreviousJustified Checkpoint `json:"previous_justified"`
+	CurrentJustified  Checkpoint `json:"current_justified"`
+	Finalized         Checkpoint `json:"finalized"`
+}
+
+// FinalityCheckpointsResponse represents the response from /eth/v1/beacon/states/{state_id}/finality_checkpoints
+type FinalityCheckpointsResponse struct {
+	ExecutionOptimistic bool                `json:"execution_optimistic"`
+	Finalized           bool                `json:"finalized"`
+	Data                FinalityCheckpoints `json:"data"`
+}
+
+// GetFinalityCheckpoints retrieves the finality checkpoints for the requested state
+// Endpoint: GET /eth/v1/beacon/states/{state_id}/finality_checkpoints
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+func (c *Client) GetFinalityCheckpoints(ctx context.Context, stateID string) (*FinalityCheckpointsResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/states/"+stateID+"/finality_checkpoints", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp FinalityCheckpointsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StateSSZResponse represents an SSZ encoded beacon state with its metadata
+type StateSSZResponse struct {
+	// Version is the consensus version of the state
+	Version ConsensusVersion
+	// ExecutionOptimistic is true if the response references an unverified execution payload
+	ExecutionOptimistic bool
+	// Finalized is true if the response references the finalized history of the chain
+	Finalized bool
+	// Data is the SSZ encoded BeaconState of the version's fork
+	Data []byte
+}
+
+// GetStateSSZ retrieves the full beacon state for a given state id using SSZ encoding
+// Endpoint: GET /eth/v2/debug/beacon/states/{state_id}
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+//
+// States are large, so the raw SSZ bytes are returned to be decoded or stored by the caller,
+// e.g. with the BeaconStateType of the zrnt fork package matching Version.
+// Nodes answering with JSON despite the Accept header are reported as an error.
+func (c *Client) GetStateSSZ(ctx context.Context, stateID string) (*StateSSZResponse, error) {
+	resp, err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		endpoint:   "/eth/v2/debug/beacon/states/" + stateID,
+		accept:     contentTypeSSZ,
+		idempotent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.isSSZ() {
+		return nil, fmt.Errorf("expected SSZ encoded state, got Content-Type %q", resp.header.Get("Content-Type"))
+	}
+
+	return &StateSSZResponse{
+		Version:             resp.consensusVersion(),
+		ExecutionOptimistic: resp.boolHeader("Eth-Execution-Optimistic"),
+		Finalized:           resp.boolHeader("Eth-Finalized"),
+		Data:                resp.body,
+	}, nil
+}
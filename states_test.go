@@ -0,0 +1,216 @@
+package beaconclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+func TestGetStateRoot_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/root" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": true,
+			"finalized": false,
+			"data": {
+				"root": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetStateRoot(context.Background(), "head")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.ExecutionOptimistic {
+		t.Error("expected execution_optimistic true")
+	}
+	if resp.Finalized {
+		t.Error("expected finalized false")
+	}
+	expectedRoot := common.HexToHash("0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2")
+	if resp.Data.Root != expectedRoot {
+		t.Errorf("unexpected root: %s", resp.Data.Root.Hex())
+	}
+}
+
+func TestGetStateRoot_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 404, "message": "State not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.GetStateRoot(context.Background(), "999999999")
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Code != 404 {
+		t.Errorf("expected code 404, got %d", apiErr.Code)
+	}
+}
+
+func TestGetStateFork_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/finalized/fork" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": true,
+			"data": {
+				"previous_version": "0x05000000",
+				"current_version": "0x06000000",
+				"epoch": "411392"
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetStateFork(context.Background(), "finalized")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.Finalized {
+		t.Error("expected finalized true")
+	}
+	if resp.Data.PreviousVersion != (zrntcommon.Version{0x05, 0, 0, 0}) {
+		t.Errorf("unexpected previous_version: %s", resp.Data.PreviousVersion)
+	}
+	if resp.Data.CurrentVersion != (zrntcommon.Version{0x06, 0, 0, 0}) {
+		t.Errorf("unexpected current_version: %s", resp.Data.CurrentVersion)
+	}
+	if resp.Data.Epoch != 411392 {
+		t.Errorf("expected epoch 411392, got %d", resp.Data.Epoch)
+	}
+}
+
+func TestGetFinalityCheckpoints_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/finality_checkpoints" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": false,
+			"data": {
+				"previous_justified": {
+					"epoch": "100",
+					"root": "0x0000000000000000000000000000000000000000000000000000000000000001"
+				},
+				"current_justified": {
+					"epoch": "101",
+					"root": "0x0000000000000000000000000000000000000000000000000000000000000002"
+				},
+				"finalized": {
+					"epoch": "99",
+					"root": "0x0000000000000000000000000000000000000000000000000000000000000003"
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetFinalityCheckpoints(context.Background(), "head")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Data.PreviousJustified.Epoch != 100 {
+		t.Errorf("expected previous_justified epoch 100, got %d", resp.Data.PreviousJustified.Epoch)
+	}
+	if resp.Data.CurrentJustified.Epoch != 101 {
+		t.Errorf("expected current_justified epoch 101, got %d", resp.Data.CurrentJustified.Epoch)
+	}
+	if resp.Data.Finalized.Epoch != 99 {
+		t.Errorf("expected finalized epoch 99, got %d", resp.Data.Finalized.Epoch)
+	}
+	expectedRoot := common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000003")
+	if resp.Data.Finalized.Root != expectedRoot {
+		t.Errorf("unexpected finalized root: %s", resp.Data.Finalized.Root.Hex())
+	}
+}
+
+func TestGetStateSSZ_Success(t *testing.T) {
+	state := []byte{0xde, 0xad, 0xbe, 0xef}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v2/debug/beacon/states/finalized" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Accept") != "application/octet-stream" {
+			t.Errorf("unexpected Accept header: %s", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Eth-Consensus-Version", "electra")
+		w.Header().Set("Eth-Execution-Optimistic", "true")
+		w.Header().Set("Eth-Finalized", "true")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(state)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetStateSSZ(context.Background(), "finalized")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Version != ConsensusVersionElectra {
+		t.Errorf("expected version electra, got %s", resp.Version)
+	}
+	if !resp.ExecutionOptimistic {
+		t.Error("expected execution_optimistic true")
+	}
+	if !resp.Finalized {
+		t.Error("expected finalized true")
+	}
+	if !bytes.Equal(resp.Data, state) {
+		t.Errorf("unexpected state data: %x", resp.Data)
+	}
+}
+
+func TestGetStateSSZ_JSONResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Eth-Consensus-Version", "electra")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"version":"electra","data":{"slot":"1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.GetStateSSZ(context.Background(), "finalized")
+	if err == nil || !strings.Contains(err.Error(), "expected SSZ encoded state") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// ValidatorStatus represents the status of a validator
+type ValidatorStatus string
+
+const (
+	ValidatorStatusPendingInitialized ValidatorStatus = "pending_initialized"
+	ValidatorStatusPendingQueued      ValidatorStatus = "pending_queued"
+	ValidatorStatusActiveOngoing      ValidatorStatus = "active_ongoing"
+	ValidatorStatusActiveExiting      ValidatorStatus = "active_exiting"
+	ValidatorStatusActiveSlashed      ValidatorStatus = "active_slashed"
+	ValidatorStatusExitedUnslashed    ValidatorStatus = "exited_unslashed"
+	ValidatorStatusExitedSlashed      ValidatorStatus = "exited_slashed"
+	ValidatorStatusWithdrawalPossible ValidatorStatus = "withdrawal_possible"
+	ValidatorStatusWithdrawalDone     ValidatorStatus = "withdrawal_done"
+
+	// The following statuses are only valid as filters and match all statuses of the group
+	ValidatorStatusPending    ValidatorStatus = "pending"
+	ValidatorStatusActive     ValidatorStatus = "active"
+	ValidatorStatusExited     ValidatorStatus = "exited"
+	ValidatorStatusWithdrawal ValidatorStatus = "withdrawal"
+)
+
+// maxValidatorIDsInQuery is the number of validator ids above which the POST form is used,
+// to keep the URL below the length limits of common HTTP servers
+const maxValidatorIDsInQuery = 32
+
+// Validator contains the validator record of the beacon state
+type Validator struct {
+	// Pubkey is the BLS public key of the validator
+	Pubkey zrntcommon.BLSPubkey `json:"pubkey"`
+	// WithdrawalCredentials is the commitment to the withdrawal key or address
+	WithdrawalCredentials common.Hash `json:"withdrawal_credentials"`
+	// EffectiveBalance is the balance in Gwei used for rewards and penalties
+	EffectiveBalance uint64 `json:"effective_balance,string"`
+	// Slashed is true if the validator has been slashed
+	Slashed bool `json:"slashed"`
+	// ActivationEligibilityEpoch is the epoch when the validator became eligible for activation
+	ActivationEligibilityEpoch uint64 `json:"activation_eligibility_epoch,string"`
+	// ActivationEpoch is the epoch when the validator became or will become active
+	ActivationEpoch uint64 `json:"activation_epoch,string"`
+	// ExitEpoch is the epoch when the validator exited or will exit
+	ExitEpoch uint64 `json:"exit_epoch,string"`
+	// WithdrawableEpoch is the epoch when the validator can withdraw its balance
+	WithdrawableEpoch uint64 `json:"withdrawable_epoch,string"`
+}
+
+// ValidatorData contains a validator with its index, balance and status
+type ValidatorData struct {
+	// Index is the index of the validator in the validator registry
+	Index uint64 `json:"index,string"`
+	// Balance is the current validator balance in Gwei
+	Balance uint64 `json:"balance,string"`
+	// Status is the current status of the validator
+	Status ValidatorStatus `json:"status"`
+	// Validator is the validator record
+	Validator Validator `json:"validator"`
+}
+
+// ValidatorsResponse represents the response from /eth/v1/beacon/states/{state_id}/validators
+type ValidatorsResponse struct {
+	ExecutionOptimistic bool            `json:"execution_optimistic"`
+	Finalized           bool            `json:"finalized"`
+	Data                []ValidatorData `json:"data"`
+}
+
+// validatorsRequest represents the request body of POST /eth/v1/beacon/states/{state_id}/validators
+type validatorsRequest struct {
+	IDs      []string          `json:"ids,omitempty"`
+	Statuses []ValidatorStatus `json:"statuses,omitempty"`
+}
+
+// GetValidators retrieves validators of the given state, optionally filtered by ids and statuses
+// Endpoint: GET /eth/v1/beacon/states/{state_id}/validators
+// Endpoint: POST /eth/v1/beacon/states/{state_id}/validators
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+// ids can be validator indices or hex encoded public keys with 0x prefix
+//
+// The POST form is used when more ids are given than fit into a URL, the GET form otherwise.
+func (c *Client) GetValidators(ctx context.Context, stateID string, ids []string, statuses []ValidatorStatus) (*ValidatorsResponse, error) {
+	endpoint := "/eth/v1/beacon/states/" + stateID + "/validators"
+
+	var body []byte
+	var err error
+	if len(ids) > maxValidatorIDsInQuery {
+		body, err = c.doQueryJSONRequest(ctx, endpoint, nil, validatorsRequest{IDs: ids, Statuses: statuses})
+	} else {
+		var query url.Values
+		if len(ids) > 0 || len(statuses) > 0 {
+			query = url.Values{}
+			for _, id := range ids {
+				query.Add("id", id)
+			}
+			for _, status := range statuses {
+				query.Add("status", string(status))
+			}
+		}
+		body, err = c.doRequest(ctx, http.MethodGet, endpoint, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ValidatorsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ValidatorResponse represents the response from /eth/v1/beacon/states/{state_id}/validators/{validator_id}
+type ValidatorResponse struct {
+	ExecutionOptimistic bool          `json:"execution_optimistic"`
+	Finalized           bool          `json:"finalized"`
+	Data                ValidatorData `json:"data"`
+}
+
+// GetValidator retrieves a single validator of the given state
+// Endpoint: GET /eth/v1/beacon/states/{state_id}/validators/{validator_id}
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+// validator_id can be a validator index or a hex encoded public key with 0x prefix
+func (c *Client) GetValidator(ctx context.Context, stateID, validatorID string) (*ValidatorResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/eth/v1/beacon/states/"+stateID+"/validators/"+validatorID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ValidatorResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ValidatorBalance contains the balance of a validator
+type ValidatorBalance struct {
+	// Index is the index of the validator in the validator registry
+	Index uint64 `json:"index,string"`
+	// Balance is the current validator balance in Gwei
+	Balance uint64 `json:"balance,string"`
+}
+
+// ValidatorBalancesResponse represents the response from /eth/v1/beacon/states/{state_id}/validator_balances
+type ValidatorBalancesResponse struct {
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+	Finalized           bool               `json:"finalized"`
+	Data                []ValidatorBalance `json:"data"`
+}
+
+// GetValidatorBalances retrieves balances of validators of the given state, optionally filtered by ids
+// Endpoint: GET /eth/v1/beacon/states/{state_id}/validator_balances
+// Endpoint: POST /eth/v1/beacon/states/{state_id}/validator_balances
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+// ids can be validator indices or hex encoded public keys with 0x prefix
+//
+// The POST form is used when more ids are given than fit into a URL, the GET form otherwise.
+func (c *Client) GetValidatorBalances(ctx context.Context, stateID string, ids []string) (*ValidatorBalancesResponse, error) {
+	endpoint := "/eth/v1/beacon/states/" + stateID + "/validator_balances"
+
+	var body []byte
+	var err error
+	if len(ids) > maxValidatorIDsInQuery {
+		body, err = c.doQueryJSONRequest(ctx, endpoint, nil, ids)
+	} else {
+		var query url.Values
+		if len(ids) > 0 {
+			query = url.Values{}
+			for _, id := range ids {
+				query.Add("id", id)
+			}
+		}
+		body, err = c.doRequest(ctx, http.MethodGet, endpoint, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ValidatorBalancesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ValidatorIdentity contains the identity of a validator
+type ValidatorIdentity struct {
+	// Index is the index of the validator in the validator registry
+	Index uint64 `json:"index,string"`
+	// Pubkey is the BLS public key of the validator
+	Pubkey zrntcommon.BLSPubkey `json:"pubkey"`
+	// ActivationEpoch is the epoch when the validator became or will become active
+	ActivationEpoch uint64 `json:"activation_epoch,string"`
+}
+
+// ValidatorIdentitiesResponse represents the response from /eth/v1/beacon/states/{state_id}/validator_identities
+type ValidatorIdentitiesResponse struct {
+	ExecutionOptimistic bool                `json:"execution_optimistic"`
+	Finalized           bool                `json:"finalized"`
+	Data                []ValidatorIdentity `json:"data"`
+}
+
+// GetValidatorIdentities retrieves index, public key and activation epoch of validators of the given state
+// Endpoint: POST /eth/v1/beacon/states/{state_id}/validator_identities
+//
+// state_id can be: "head", "genesis", "finalized", "justified", <slot>, <hex encoded stateRoot with 0x prefix>
+// ids can be validator indices or hex encoded public keys with 0x prefix, all validators are returned if empty
+func (c *Client) GetValidatorIdentities(ctx context.Context, stateID string, ids []string) (*ValidatorIdentitiesResponse, error) {
+	if ids == nil {
+		ids = []string{}
+	}
+
+	body, err := c.doQueryJSONRequest(ctx, "/eth/v1/beacon/states/"+stateID+"/validator_identities", nil, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ValidatorIdentitiesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
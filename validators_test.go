@@ -0,0 +1,297 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+const testValidatorJSON = `{
+	"index": "1",
+	"balance": "32000000000",
+	"status": "active_ongoing",
+	"validator": {
+		"pubkey": "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a",
+		"withdrawal_credentials": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
+		"effective_balance": "32000000000",
+		"slashed": false,
+		"activation_eligibility_epoch": "0",
+		"activation_epoch": "0",
+		"exit_epoch": "18446744073709551615",
+		"withdrawable_epoch": "18446744073709551615"
+	}
+}`
+
+func TestGetValidators_WithFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/validators" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		ids := r.URL.Query()["id"]
+		if len(ids) != 2 || ids[0] != "1" || ids[1] != "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a" {
+			t.Errorf("unexpected id filter: %v", ids)
+		}
+		statuses := r.URL.Query()["status"]
+		if len(statuses) != 1 || statuses[0] != "active" {
+			t.Errorf("unexpected status filter: %v", statuses)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"execution_optimistic": false, "finalized": false, "data": [` + testValidatorJSON + `]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetValidators(context.Background(), "head",
+		[]string{"1", "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"},
+		[]ValidatorStatus{ValidatorStatusActive})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 1 {
+		t.Fatalf("expected 1 validator, got %d", len(resp.Data))
+	}
+	validator := resp.Data[0]
+	if validator.Index != 1 {
+		t.Errorf("expected index 1, got %d", validator.Index)
+	}
+	if validator.Balance != 32000000000 {
+		t.Errorf("expected balance 32000000000, got %d", validator.Balance)
+	}
+	if validator.Status != ValidatorStatusActiveOngoing {
+		t.Errorf("unexpected status: %s", validator.Status)
+	}
+	if validator.Validator.Pubkey.String() != "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a" {
+		t.Errorf("unexpected pubkey: %s", validator.Validator.Pubkey)
+	}
+	if validator.Validator.ExitEpoch != 18446744073709551615 {
+		t.Errorf("unexpected exit_epoch: %d", validator.Validator.ExitEpoch)
+	}
+}
+
+func TestGetValidators_PostForManyIDs(t *testing.T) {
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/finalized/validators" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected Content-Type header: %s", r.Header.Get("Content-Type"))
+		}
+
+		var req struct {
+			IDs      []string `json:"ids"`
+			Statuses []string `json:"statuses"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if len(req.IDs) != len(ids) {
+			t.Errorf("expected %d ids, got %d", len(ids), len(req.IDs))
+		}
+		if len(req.Statuses) != 1 || req.Statuses[0] != "exited_slashed" {
+			t.Errorf("unexpected statuses: %v", req.Statuses)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"execution_optimistic": false, "finalized": true, "data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetValidators(context.Background(), "finalized", ids, []ValidatorStatus{ValidatorStatusExitedSlashed})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Finalized {
+		t.Error("expected finalized true")
+	}
+}
+
+func TestGetValidator_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/validators/1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"execution_optimistic": false, "finalized": false, "data": ` + testValidatorJSON + `}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetValidator(context.Background(), "head", "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Data.Index != 1 {
+		t.Errorf("expected index 1, got %d", resp.Data.Index)
+	}
+	if resp.Data.Validator.EffectiveBalance != 32000000000 {
+		t.Errorf("expected effective_balance 32000000000, got %d", resp.Data.Validator.EffectiveBalance)
+	}
+}
+
+func TestGetValidator_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 404, "message": "Validator not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.GetValidator(context.Background(), "head", "999999999")
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Code != 404 {
+		t.Errorf("expected code 404, got %d", apiErr.Code)
+	}
+}
+
+func TestGetValidatorBalances_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/validator_balances" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if ids := r.URL.Query()["id"]; len(ids) != 2 {
+			t.Errorf("unexpected id filter: %v", ids)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": false,
+			"data": [
+				{"index": "1", "balance": "32000000000"},
+				{"index": "2", "balance": "2048000000000"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetValidatorBalances(context.Background(), "head", []string{"1", "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 balances, got %d", len(resp.Data))
+	}
+	if resp.Data[1].Index != 2 {
+		t.Errorf("expected index 2, got %d", resp.Data[1].Index)
+	}
+	if resp.Data[1].Balance != 2048000000000 {
+		t.Errorf("expected balance 2048000000000, got %d", resp.Data[1].Balance)
+	}
+}
+
+func TestGetValidatorBalances_PostForManyIDs(t *testing.T) {
+	ids := make([]string, 5000)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+
+		var req []string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if len(req) != len(ids) {
+			t.Errorf("expected %d ids, got %d", len(ids), len(req))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"execution_optimistic": false, "finalized": false, "data": [{"index": "4999", "balance": "1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetValidatorBalances(context.Background(), "head", ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Index != 4999 {
+		t.Errorf("unexpected balances: %+v", resp.Data)
+	}
+}
+
+func TestGetValidatorIdentities_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/validator_identities" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+
+		var req []string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if req == nil || len(req) != 0 {
+			t.Errorf("expected empty id array, got %v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"execution_optimistic": false,
+			"finalized": false,
+			"data": [
+				{
+					"index": "1",
+					"pubkey": "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a",
+					"activation_epoch": "10"
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.GetValidatorIdentities(context.Background(), "head", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 1 {
+		t.Fatalf("expected 1 identity, got %d", len(resp.Data))
+	}
+	if resp.Data[0].Index != 1 {
+		t.Errorf("expected index 1, got %d", resp.Data[0].Index)
+	}
+	if resp.Data[0].ActivationEpoch != 10 {
+		t.Errorf("expected activation_epoch 10, got %d", resp.Data[0].ActivationEpoch)
+	}
+}
@@ -0,0 +1,157 @@
+package beaconclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// BlockVerificationError reports a block that does not match the root or header it was checked against
+type BlockVerificationError struct {
+	// Field is the mismatching value, e.g. "block root" or "body root"
+	Field string
+	// Expected is the value reported by the beacon node or requested by the caller
+	Expected common.Hash
+	// Computed is the value computed from the block
+	Computed common.Hash
+}
+
+func (e *BlockVerificationError) Error() string {
+	return fmt.Sprintf("%s mismatch: expected %s, computed %s", e.Field, e.Expected.Hex(), e.Computed.Hex())
+}
+
+// VerifyBlockRoot checks that the hash tree root of the block equals root
+// The spec (see GetSpec) provides the SSZ list limits used for hashing
+func VerifyBlockRoot(spec *Spec, block *VersionedBeaconBlock, root common.Hash) error {
+	if spec == nil {
+		return fmt.Errorf("spec is required to verify blocks")
+	}
+	if block == nil {
+		return fmt.Errorf("missing block")
+	}
+	computed, err := block.HashTreeRoot(spec)
+	if err != nil {
+		return err
+	}
+	if computed != root {
+		return &BlockVerificationError{Field: "block root", Expected: root, Computed: computed}
+	}
+	return nil
+}
+
+// VerifyBlockHeader checks that the header matches the block, including the root of the block body
+func VerifyBlockHeader(spec *Spec, block *VersionedBeaconBlock, header *BeaconBlockHeader) error {
+	if spec == nil {
+		return fmt.Errorf("spec is required to verify blocks")
+	}
+	if block == nil {
+		return fmt.Errorf("missing block")
+	}
+	if header == nil {
+		return fmt.Errorf("missing block header")
+	}
+
+	computed, err := block.Header(spec)
+	if err != nil {
+		return err
+	}
+	if header.Slot != computed.Slot {
+		return fmt.Errorf("header slot mismatch: expected %d, block has %d", header.Slot, computed.Slot)
+	}
+	if header.ProposerIndex != computed.ProposerIndex {
+		return fmt.Errorf("header proposer index mismatch: expected %d, block has %d", header.ProposerIndex, computed.ProposerIndex)
+	}
+	if header.ParentRoot != computed.ParentRoot {
+		return &BlockVerificationError{Field: "parent root", Expected: header.ParentRoot, Computed: computed.ParentRoot}
+	}
+	if header.StateRoot != computed.StateRoot {
+		return &BlockVerificationError{Field: "state root", Expected: header.StateRoot, Computed: computed.StateRoot}
+	}
+	if header.BodyRoot != computed.BodyRoot {
+		return &BlockVerificationError{Field: "body root", Expected: header.BodyRoot, Computed: computed.BodyRoot}
+	}
+	return nil
+}
+
+// HashTreeRoot returns the hash tree root of the header, which equals the root of its block
+func (h *BeaconBlockHeader) HashTreeRoot() common.Hash {
+	header := zrntcommon.BeaconBlockHeader{
+		Slot:          zrntcommon.Slot(h.Slot),
+		ProposerIndex: zrntcommon.ValidatorIndex(h.ProposerIndex),
+		ParentRoot:    zrntcommon.Root(h.ParentRoot),
+		StateRoot:     zrntcommon.Root(h.StateRoot),
+		BodyRoot:      zrntcommon.Root(h.BodyRoot),
+	}
+	return common.Hash(header.HashTreeRoot(tree.GetHashFn()))
+}
+
+// VerifyBlock checks a block fetched for blockID against the roots reported by the beacon node
+//
+// If blockID is a block root, the hash tree root of the block must equal it, otherwise it must
+// equal the root returned by GetBlockRoot for the slot of the block. The slot is used instead of
+// blockID, as ids such as "head" may point to another block by the time the root is requested.
+// A slot blockID must equal the slot of the block. The header returned by GetBlockHeader for
+// the root must match the block, including its body root, and hash to the same root.
+// A *BlockVerificationError is returned for mismatching roots.
+func (c *Client) VerifyBlock(ctx context.Context, spec *Spec, blockID string, block *VersionedBeaconBlock) error {
+	if spec == nil {
+		return fmt.Errorf("spec is required to verify blocks")
+	}
+	if block == nil {
+		return fmt.Errorf("missing block")
+	}
+
+	root, isRoot, err := parseBlockRootID(blockID)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		slot := block.Slot()
+		if requested, err := strconv.ParseUint(blockID, 10, 64); err == nil && requested != slot {
+			return fmt.Errorf("block slot mismatch: requested %d, block has %d", requested, slot)
+		}
+		resp, err := c.GetBlockRoot(ctx, strconv.FormatUint(slot, 10))
+		if err != nil {
+			return err
+		}
+		root = resp.Data.Root
+	}
+
+	if err := VerifyBlockRoot(spec, block, root); err != nil {
+		return err
+	}
+
+	header, err := c.GetBlockHeader(ctx, root.Hex())
+	if err != nil {
+		return err
+	}
+	if header.Data.Root != root {
+		return &BlockVerificationError{Field: "header root", Expected: root, Computed: header.Data.Root}
+	}
+	if computed := header.Data.Header.Message.HashTreeRoot(); computed != root {
+		return &BlockVerificationError{Field: "header root", Expected: root, Computed: computed}
+	}
+	return VerifyBlockHeader(spec, block, &header.Data.Header.Message)
+}
+
+// parseBlockRootID parses a block id with 0x prefix as hex encoded block root
+// isRoot is false for the other block ids, such as "head" or a slot.
+func parseBlockRootID(blockID string) (root common.Hash, isRoot bool, err error) {
+	if !strings.HasPrefix(blockID, "0x") {
+		return common.Hash{}, false, nil
+	}
+	data, err := hexutil.Decode(blockID)
+	if err != nil {
+		return common.Hash{}, true, fmt.Errorf("invalid block root %q: %w", blockID, err)
+	}
+	if len(data) != common.HashLength {
+		return common.Hash{}, true, fmt.Errorf("invalid block root %q: expected %d bytes, got %d", blockID, common.HashLength, len(data))
+	}
+	return common.Hash(data), true, nil
+}
@@ -0,0 +1,243 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/protolambda/zrnt/eth2/configs"
+)
+
+// newBlockRootServer serves the root and header endpoints for a single block
+// The root is only served by slot, any other block id such as "head" is unexpected.
+func newBlockRootServer(t *testing.T, root common.Hash, header BeaconBlockHeader) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp any
+		switch r.URL.Path {
+		case "/eth/v1/beacon/blocks/" + strconv.FormatUint(header.Slot, 10) + "/root":
+			resp = BlockRootResponse{Data: BlockRootData{Root: root}}
+		case "/eth/v1/beacon/headers/" + root.Hex():
+			resp = BlockHeaderResponse{Data: BlockHeaderData{
+				Root:      root,
+				Canonical: true,
+				Header:    SignedBeaconBlockHeader{Message: header},
+			}}
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		data, err := json.Marshal(resp)
+		if err != nil {
+			t.Fatalf("failed to encode response: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}))
+}
+
+func loadVersionedBlock(t *testing.T, testdataFile string) *VersionedBeaconBlock {
+	t.Helper()
+	block, err := loadSignedBlock(t, testdataFile).ParseBlock()
+	if err != nil {
+		t.Fatalf("failed to parse block: %v", err)
+	}
+	return block
+}
+
+// testBlockRoot returns the block root of a testdata block
+func testBlockRoot(t *testing.T, spec *Spec, block *VersionedBeaconBlock) common.Hash {
+	t.Helper()
+	root, err := block.HashTreeRoot(spec)
+	if err != nil {
+		t.Fatalf("failed to hash block: %v", err)
+	}
+	return root
+}
+
+// testBlockHeader returns the header of a testdata block
+func testBlockHeader(t *testing.T, spec *Spec, block *VersionedBeaconBlock) *BeaconBlockHeader {
+	t.Helper()
+	header, err := block.Header(spec)
+	if err != nil {
+		t.Fatalf("failed to compute block header: %v", err)
+	}
+	return header
+}
+
+func TestVerifyBlock_Success(t *testing.T) {
+	spec := &Spec{Spec: *configs.Mainnet}
+	block := loadVersionedBlock(t, "testdata/fulu.block.json")
+	header := testBlockHeader(t, spec, block)
+	root := header.HashTreeRoot()
+	if root != testBlockRoot(t, spec, block) {
+		t.Fatal("header root does not match block root")
+	}
+
+	server := newBlockRootServer(t, root, *header)
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	// the root of "head" is requested by slot, so a head that moved on meanwhile does not matter
+	for _, blockID := range []string{"head", "13410020", root.Hex()} {
+		if err := client.VerifyBlock(context.Background(), spec, blockID, block); err != nil {
+			t.Errorf("unexpected error for %s: %v", blockID, err)
+		}
+	}
+}
+
+func TestVerifyBlock_Mismatch(t *testing.T) {
+	spec := &Spec{Spec: *configs.Mainnet}
+	block := loadVersionedBlock(t, "testdata/electra.block.json")
+	header := testBlockHeader(t, spec, block)
+	root := testBlockRoot(t, spec, block)
+
+	tests := []struct {
+		name      string
+		root      common.Hash
+		header    BeaconBlockHeader
+		wantField string
+	}{
+		{
+			name:      "forged root",
+			root:      common.Hash{0x01},
+			header:    *header,
+			wantField: "block root",
+		},
+		{
+			name: "forged body root",
+			root: root,
+			header: func() BeaconBlockHeader {
+				forged := *header
+				forged.BodyRoot = common.Hash{0x02}
+				return forged
+			}(),
+			wantField: "header root",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newBlockRootServer(t, tt.root, tt.header)
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			err := client.VerifyBlock(context.Background(), spec, "head", block)
+			var verr *BlockVerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected *BlockVerificationError, got %T: %v", err, err)
+			}
+			if verr.Field != tt.wantField {
+				t.Errorf("expected field %q, got %q", tt.wantField, verr.Field)
+			}
+		})
+	}
+}
+
+func TestVerifyBlock_SlotMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	spec := &Spec{Spec: *configs.Mainnet}
+	block := loadVersionedBlock(t, "testdata/fulu.block.json")
+	client := NewClient(server.URL)
+	err := client.VerifyBlock(context.Background(), spec, "13410021", block)
+	if err == nil || !strings.Contains(err.Error(), "block slot mismatch") {
+		t.Errorf("expected a slot mismatch, got %v", err)
+	}
+	if err := client.VerifyBlock(context.Background(), spec, "head", nil); err == nil {
+		t.Error("expected error for a nil block")
+	}
+}
+
+func TestVerifyBlock_InvalidBlockRoot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	spec := &Spec{Spec: *configs.Mainnet}
+	block := loadVersionedBlock(t, "testdata/electra.block.json")
+	client := NewClient(server.URL)
+	for _, blockID := range []string{
+		"0x" + strings.Repeat("zz", common.HashLength),
+		"0x" + strings.Repeat("ab", common.HashLength-1),
+		"0x",
+	} {
+		err := client.VerifyBlock(context.Background(), spec, blockID, block)
+		if err == nil || !strings.Contains(err.Error(), "invalid block root") {
+			t.Errorf("unexpected error for %s: %v", blockID, err)
+		}
+		var verr *BlockVerificationError
+		if errors.As(err, &verr) {
+			t.Errorf("expected an invalid argument error for %s, got %v", blockID, verr)
+		}
+	}
+}
+
+func TestVerifyBlockHeader_BodyRoot(t *testing.T) {
+	spec := &Spec{Spec: *configs.Mainnet}
+	block := loadVersionedBlock(t, "testdata/deneb.block.json")
+	header := testBlockHeader(t, spec, block)
+	if err := VerifyBlockHeader(spec, block, header); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	header.BodyRoot = common.Hash{0x03}
+	err := VerifyBlockHeader(spec, block, header)
+	var verr *BlockVerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *BlockVerificationError, got %T: %v", err, err)
+	}
+	if verr.Field != "body root" || verr.Computed != testBlockHeader(t, spec, block).BodyRoot {
+		t.Errorf("unexpected error: %v", verr)
+	}
+}
+
+func TestVerifyBlock_MissingInput(t *testing.T) {
+	spec := &Spec{Spec: *configs.Mainnet}
+	block := loadVersionedBlock(t, "testdata/deneb.block.json")
+	if err := VerifyBlockHeader(spec, block, nil); err == nil {
+		t.Error("expected error for a nil header")
+	}
+	if err := VerifyBlockHeader(spec, nil, testBlockHeader(t, spec, block)); err == nil {
+		t.Error("expected error for a nil block")
+	}
+	if err := VerifyBlockRoot(spec, nil, common.Hash{}); err == nil {
+		t.Error("expected error for a nil block")
+	}
+}
+
+func TestVerifyBlockRoot_AllForks(t *testing.T) {
+	spec := &Spec{Spec: *configs.Mainnet}
+	files := []string{
+		"testdata/phase0.block.json",
+		"testdata/altair.block.json",
+		"testdata/bellatrix.block.json",
+		"testdata/cepella.block.json",
+		"testdata/deneb.block.json",
+		"testdata/electra.block.json",
+		"testdata/fulu.block.json",
+	}
+	for _, file := range files {
+		block := loadVersionedBlock(t, file)
+		root := testBlockHeader(t, spec, block).HashTreeRoot()
+		if root == (common.Hash{}) {
+			t.Errorf("%s: empty root", file)
+		}
+		if err := VerifyBlockRoot(spec, block, root); err != nil {
+			t.Errorf("%s: unexpected error: %v", file, err)
+		}
+	}
+}
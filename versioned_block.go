@@ -0,0 +1,479 @@
+package beaconclient
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// VersionedBeaconBlock is a beacon block of any fork
+// Exactly one of the fork fields, matching Version, is set
+type VersionedBeaconBlock struct {
+	Version   ConsensusVersion
+	Phase0    *phase0.BeaconBlock
+	Altair    *altair.BeaconBlock
+	Bellatrix *bellatrix.BeaconBlock
+	Capella   *capella.BeaconBlock
+	Deneb     *deneb.BeaconBlock
+	Electra   *electra.BeaconBlock
+	Fulu      *FuluBeaconBlock
+}
+
+// newVersionedBeaconBlock wraps a zrnt beacon block of the given version
+func newVersionedBeaconBlock(version ConsensusVersion, block any) (*VersionedBeaconBlock, error) {
+	versioned := &VersionedBeaconBlock{Version: version}
+	ok := false
+	switch version {
+	case ConsensusVersionPhase0:
+		versioned.Phase0, ok = block.(*phase0.BeaconBlock)
+	case ConsensusVersionAltair:
+		versioned.Altair, ok = block.(*altair.BeaconBlock)
+	case ConsensusVersionBellatrix:
+		versioned.Bellatrix, ok = block.(*bellatrix.BeaconBlock)
+	case ConsensusVersionCapella:
+		versioned.Capella, ok = block.(*capella.BeaconBlock)
+	case ConsensusVersionDeneb:
+		versioned.Deneb, ok = block.(*deneb.BeaconBlock)
+	case ConsensusVersionElectra:
+		versioned.Electra, ok = block.(*electra.BeaconBlock)
+	case ConsensusVersionFulu:
+		versioned.Fulu, ok = block.(*FuluBeaconBlock)
+	default:
+		return nil, fmt.Errorf("unsupported consensus version: %s", version)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s block type: %T", version, block)
+	}
+	return versioned, nil
+}
+
+// newBeaconBlock returns an empty zrnt beacon block for the given version
+func newBeaconBlock(version ConsensusVersion) (any, error) {
+	switch version {
+	case ConsensusVersionPhase0:
+		return new(phase0.BeaconBlock), nil
+	case ConsensusVersionAltair:
+		return new(altair.BeaconBlock), nil
+	case ConsensusVersionBellatrix:
+		return new(bellatrix.BeaconBlock), nil
+	case ConsensusVersionCapella:
+		return new(capella.BeaconBlock), nil
+	case ConsensusVersionDeneb:
+		return new(deneb.BeaconBlock), nil
+	case ConsensusVersionElectra:
+		return new(electra.BeaconBlock), nil
+	case ConsensusVersionFulu:
+		return new(FuluBeaconBlock), nil
+	default:
+		return nil, fmt.Errorf("unsupported consensus version: %s", version)
+	}
+}
+
+// Slot returns the slot of the block
+func (b *VersionedBeaconBlock) Slot() uint64 {
+	switch {
+	case b.Phase0 != nil:
+		return uint64(b.Phase0.Slot)
+	case b.Altair != nil:
+		return uint64(b.Altair.Slot)
+	case b.Bellatrix != nil:
+		return uint64(b.Bellatrix.Slot)
+	case b.Capella != nil:
+		return uint64(b.Capella.Slot)
+	case b.Deneb != nil:
+		return uint64(b.Deneb.Slot)
+	case b.Electra != nil:
+		return uint64(b.Electra.Slot)
+	case b.Fulu != nil:
+		return uint64(b.Fulu.Slot)
+	default:
+		return 0
+	}
+}
+
+// ProposerIndex returns the validator index of the block proposer
+func (b *VersionedBeaconBlock) ProposerIndex() uint64 {
+	switch {
+	case b.Phase0 != nil:
+		return uint64(b.Phase0.ProposerIndex)
+	case b.Altair != nil:
+		return uint64(b.Altair.ProposerIndex)
+	case b.Bellatrix != nil:
+		return uint64(b.Bellatrix.ProposerIndex)
+	case b.Capella != nil:
+		return uint64(b.Capella.ProposerIndex)
+	case b.Deneb != nil:
+		return uint64(b.Deneb.ProposerIndex)
+	case b.Electra != nil:
+		return uint64(b.Electra.ProposerIndex)
+	case b.Fulu != nil:
+		return uint64(b.Fulu.ProposerIndex)
+	default:
+		return 0
+	}
+}
+
+// ParentRoot returns the root of the parent block
+func (b *VersionedBeaconBlock) ParentRoot() common.Hash {
+	switch {
+	case b.Phase0 != nil:
+		return common.Hash(b.Phase0.ParentRoot)
+	case b.Altair != nil:
+		return common.Hash(b.Altair.ParentRoot)
+	case b.Bellatrix != nil:
+		return common.Hash(b.Bellatrix.ParentRoot)
+	case b.Capella != nil:
+		return common.Hash(b.Capella.ParentRoot)
+	case b.Deneb != nil:
+		return common.Hash(b.Deneb.ParentRoot)
+	case b.Electra != nil:
+		return common.Hash(b.Electra.ParentRoot)
+	case b.Fulu != nil:
+		return common.Hash(b.Fulu.ParentRoot)
+	default:
+		return common.Hash{}
+	}
+}
+
+// StateRoot returns the root of the post state of the block
+func (b *VersionedBeaconBlock) StateRoot() common.Hash {
+	switch {
+	case b.Phase0 != nil:
+		return common.Hash(b.Phase0.StateRoot)
+	case b.Altair != nil:
+		return common.Hash(b.Altair.StateRoot)
+	case b.Bellatrix != nil:
+		return common.Hash(b.Bellatrix.StateRoot)
+	case b.Capella != nil:
+		return common.Hash(b.Capella.StateRoot)
+	case b.Deneb != nil:
+		return common.Hash(b.Deneb.StateRoot)
+	case b.Electra != nil:
+		return common.Hash(b.Electra.StateRoot)
+	case b.Fulu != nil:
+		return common.Hash(b.Fulu.StateRoot)
+	default:
+		return common.Hash{}
+	}
+}
+
+// HashTreeRoot returns the block root, the hash tree root of the block
+// The spec (see GetSpec) provides the SSZ list limits
+func (b *VersionedBeaconBlock) HashTreeRoot(spec *Spec) (common.Hash, error) {
+	if spec == nil {
+		return common.Hash{}, fmt.Errorf("spec is required to hash blocks")
+	}
+	if b == nil || b.block() == nil {
+		return common.Hash{}, fmt.Errorf("missing block")
+	}
+	return common.Hash(b.block().HashTreeRoot(&spec.Spec, tree.GetHashFn())), nil
+}
+
+// BodyRoot returns the hash tree root of the block body
+// The spec (see GetSpec) provides the SSZ list limits
+func (b *VersionedBeaconBlock) BodyRoot(spec *Spec) (common.Hash, error) {
+	if spec == nil {
+		return common.Hash{}, fmt.Errorf("spec is required to hash blocks")
+	}
+	if b == nil || b.body() == nil {
+		return common.Hash{}, fmt.Errorf("missing block")
+	}
+	return common.Hash(b.body().HashTreeRoot(&spec.Spec, tree.GetHashFn())), nil
+}
+
+// Header returns the header of the block
+// The spec (see GetSpec) is required to compute the body root
+func (b *VersionedBeaconBlock) Header(spec *Spec) (*BeaconBlockHeader, error) {
+	bodyRoot, err := b.BodyRoot(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &BeaconBlockHeader{
+		Slot:          b.Slot(),
+		ProposerIndex: b.ProposerIndex(),
+		ParentRoot:    b.ParentRoot(),
+		StateRoot:     b.StateRoot(),
+		BodyRoot:      bodyRoot,
+	}, nil
+}
+
+// block returns the zrnt block of the fork
+func (b *VersionedBeaconBlock) block() zrntcommon.SpecObj {
+	switch {
+	case b.Phase0 != nil:
+		return b.Phase0
+	case b.Altair != nil:
+		return b.Altair
+	case b.Bellatrix != nil:
+		return b.Bellatrix
+	case b.Capella != nil:
+		return b.Capella
+	case b.Deneb != nil:
+		return b.Deneb
+	case b.Electra != nil:
+		return b.Electra
+	case b.Fulu != nil:
+		return b.Fulu
+	default:
+		return nil
+	}
+}
+
+// body returns the zrnt block body of the fork
+func (b *VersionedBeaconBlock) body() zrntcommon.SpecObj {
+	switch {
+	case b.Phase0 != nil:
+		return &b.Phase0.Body
+	case b.Altair != nil:
+		return &b.Altair.Body
+	case b.Bellatrix != nil:
+		return &b.Bellatrix.Body
+	case b.Capella != nil:
+		return &b.Capella.Body
+	case b.Deneb != nil:
+		return &b.Deneb.Body
+	case b.Electra != nil:
+		return &b.Electra.Body
+	case b.Fulu != nil:
+		return &b.Fulu.Body
+	default:
+		return nil
+	}
+}
+
+// Attestations returns the attestations included in the block body
+func (b *VersionedBeaconBlock) Attestations() []VersionedAttestation {
+	var phase0Attestations phase0.Attestations
+	var electraAttestations electra.Attestations
+	switch {
+	case b.Phase0 != nil:
+		phase0Attestations = b.Phase0.Body.Attestations
+	case b.Altair != nil:
+		phase0Attestations = b.Altair.Body.Attestations
+	case b.Bellatrix != nil:
+		phase0Attestations = b.Bellatrix.Body.Attestations
+	case b.Capella != nil:
+		phase0Attestations = b.Capella.Body.Attestations
+	case b.Deneb != nil:
+		phase0Attestations = b.Deneb.Body.Attestations
+	case b.Electra != nil:
+		electraAttestations = b.Electra.Body.Attestations
+	case b.Fulu != nil:
+		electraAttestations = b.Fulu.Body.Attestations
+	}
+
+	attestations := make([]VersionedAttestation, 0, len(phase0Attestations)+len(electraAttestations))
+	for i := range phase0Attestations {
+		attestations = append(attestations, VersionedAttestation{Version: b.Version, Phase0: &phase0Attestations[i]})
+	}
+	for i := range electraAttestations {
+		attestations = append(attestations, VersionedAttestation{Version: b.Version, Electra: &electraAttestations[i]})
+	}
+	return attestations
+}
+
+// ExecutionPayload returns the execution payload of the block
+// The second return value is false for blocks before Bellatrix
+func (b *VersionedBeaconBlock) ExecutionPayload() (*VersionedExecutionPayload, bool) {
+	switch {
+	case b.Bellatrix != nil:
+		return &VersionedExecutionPayload{Version: b.Version, Bellatrix: &b.Bellatrix.Body.ExecutionPayload}, true
+	case b.Capella != nil:
+		return &VersionedExecutionPayload{Version: b.Version, Capella: &b.Capella.Body.ExecutionPayload}, true
+	case b.Deneb != nil:
+		return &VersionedExecutionPayload{Version: b.Version, Deneb: &b.Deneb.Body.ExecutionPayload}, true
+	case b.Electra != nil:
+		return &VersionedExecutionPayload{Version: b.Version, Deneb: &b.Electra.Body.ExecutionPayload}, true
+	case b.Fulu != nil:
+		return &VersionedExecutionPayload{Version: b.Version, Deneb: &b.Fulu.Body.ExecutionPayload}, true
+	default:
+		return nil, false
+	}
+}
+
+// BlobKZGCommitments returns the blob KZG commitments of the block
+// The second return value is false for blocks before Deneb
+func (b *VersionedBeaconBlock) BlobKZGCommitments() ([]kzg4844.Commitment, bool) {
+	var commitments deneb.KZGCommitments
+	switch {
+	case b.Deneb != nil:
+		commitments = b.Deneb.Body.BlobKZGCommitments
+	case b.Electra != nil:
+		commitments = b.Electra.Body.BlobKZGCommitments
+	case b.Fulu != nil:
+		commitments = b.Fulu.Body.BlobKZGCommitments
+	default:
+		return nil, false
+	}
+
+	result := make([]kzg4844.Commitment, len(commitments))
+	for i, commitment := range commitments {
+		result[i] = kzg4844.Commitment(commitment)
+	}
+	return result, true
+}
+
+// VersionedAttestation is an attestation of any fork
+// Phase0 is set up to Deneb, Electra is set from Electra on
+type VersionedAttestation struct {
+	Version ConsensusVersion
+	Phase0  *phase0.Attestation
+	Electra *electra.Attestation
+}
+
+// Data returns the attestation data
+func (a *VersionedAttestation) Data() *phase0.AttestationData {
+	switch {
+	case a.Phase0 != nil:
+		return &a.Phase0.Data
+	case a.Electra != nil:
+		return &a.Electra.Data
+	default:
+		return nil
+	}
+}
+
+// AggregationBits returns the SSZ encoded aggregation bitlist
+func (a *VersionedAttestation) AggregationBits() []byte {
+	switch {
+	case a.Phase0 != nil:
+		return a.Phase0.AggregationBits
+	case a.Electra != nil:
+		return a.Electra.AggregationBits
+	default:
+		return nil
+	}
+}
+
+// CommitteeIndices returns the indices of the committees the attestation aggregates
+// Before Electra this is the single data.index, from Electra on it is derived from committee_bits
+func (a *VersionedAttestation) CommitteeIndices() []uint64 {
+	switch {
+	case a.Phase0 != nil:
+		return []uint64{uint64(a.Phase0.Data.Index)}
+	case a.Electra != nil:
+		var indices []uint64
+		for i := range len(a.Electra.CommitteeBits) * 8 {
+			if a.Electra.CommitteeBits[i/8]&(1<<(i%8)) != 0 {
+				indices = append(indices, uint64(i))
+			}
+		}
+		return indices
+	default:
+		return nil
+	}
+}
+
+// VersionedExecutionPayload is an execution payload of any fork
+// Deneb is also used for Electra and Fulu blocks, which did not change the payload
+type VersionedExecutionPayload struct {
+	Version   ConsensusVersion
+	Bellatrix *bellatrix.ExecutionPayload
+	Capella   *capella.ExecutionPayload
+	Deneb     *deneb.ExecutionPayload
+}
+
+// BlockHash returns the execution block hash
+func (p *VersionedExecutionPayload) BlockHash() common.Hash {
+	switch {
+	case p.Bellatrix != nil:
+		return common.Hash(p.Bellatrix.BlockHash)
+	case p.Capella != nil:
+		return common.Hash(p.Capella.BlockHash)
+	case p.Deneb != nil:
+		return common.Hash(p.Deneb.BlockHash)
+	default:
+		return common.Hash{}
+	}
+}
+
+// ParentHash returns the hash of the parent execution block
+func (p *VersionedExecutionPayload) ParentHash() common.Hash {
+	switch {
+	case p.Bellatrix != nil:
+		return common.Hash(p.Bellatrix.ParentHash)
+	case p.Capella != nil:
+		return common.Hash(p.Capella.ParentHash)
+	case p.Deneb != nil:
+		return common.Hash(p.Deneb.ParentHash)
+	default:
+		return common.Hash{}
+	}
+}
+
+// BlockNumber returns the execution block number
+func (p *VersionedExecutionPayload) BlockNumber() uint64 {
+	switch {
+	case p.Bellatrix != nil:
+		return uint64(p.Bellatrix.BlockNumber)
+	case p.Capella != nil:
+		return uint64(p.Capella.BlockNumber)
+	case p.Deneb != nil:
+		return uint64(p.Deneb.BlockNumber)
+	default:
+		return 0
+	}
+}
+
+// FeeRecipient returns the fee recipient of the execution block
+func (p *VersionedExecutionPayload) FeeRecipient() common.Address {
+	switch {
+	case p.Bellatrix != nil:
+		return common.Address(p.Bellatrix.FeeRecipient)
+	case p.Capella != nil:
+		return common.Address(p.Capella.FeeRecipient)
+	case p.Deneb != nil:
+		return common.Address(p.Deneb.FeeRecipient)
+	default:
+		return common.Address{}
+	}
+}
+
+// Timestamp returns the timestamp of the execution block
+func (p *VersionedExecutionPayload) Timestamp() uint64 {
+	switch {
+	case p.Bellatrix != nil:
+		return uint64(p.Bellatrix.Timestamp)
+	case p.Capella != nil:
+		return uint64(p.Capella.Timestamp)
+	case p.Deneb != nil:
+		return uint64(p.Deneb.Timestamp)
+	default:
+		return 0
+	}
+}
+
+// Transactions returns the raw encoded transactions of the execution block
+func (p *VersionedExecutionPayload) Transactions() zrntcommon.PayloadTransactions {
+	switch {
+	case p.Bellatrix != nil:
+		return p.Bellatrix.Transactions
+	case p.Capella != nil:
+		return p.Capella.Transactions
+	case p.Deneb != nil:
+		return p.Deneb.Transactions
+	default:
+		return nil
+	}
+}
+
+// Withdrawals returns the withdrawals of the execution block (nil before Capella)
+func (p *VersionedExecutionPayload) Withdrawals() zrntcommon.Withdrawals {
+	switch {
+	case p.Capella != nil:
+		return p.Capella.Withdrawals
+	case p.Deneb != nil:
+		return p.Deneb.Withdrawals
+	default:
+		return nil
+	}
+}
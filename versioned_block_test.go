@@ -0,0 +1,67 @@
+package beaconclient
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/zrnt/eth2/configs"
+)
+
+func TestVersionedAttestation_CommitteeIndices(t *testing.T) {
+	tests := []struct {
+		name        string
+		attestation VersionedAttestation
+		want        []uint64
+	}{
+		{
+			name: "phase0 uses data index",
+			attestation: VersionedAttestation{
+				Version: ConsensusVersionDeneb,
+				Phase0:  &phase0.Attestation{Data: phase0.AttestationData{Index: 7}},
+			},
+			want: []uint64{7},
+		},
+		{
+			name: "electra uses committee bits",
+			attestation: VersionedAttestation{
+				Version: ConsensusVersionElectra,
+				Electra: &electra.Attestation{CommitteeBits: electra.CommitteeBits{0b00000101, 0, 0, 0, 0, 0, 0, 0b10000000}},
+			},
+			want: []uint64{0, 2, 63},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.attestation.CommitteeIndices()
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CommitteeIndices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVersionedBeaconBlock_TypeMismatch(t *testing.T) {
+	_, err := newVersionedBeaconBlock(ConsensusVersionDeneb, &electra.BeaconBlock{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestVersionedBeaconBlock_MissingSpec(t *testing.T) {
+	block := loadVersionedBlock(t, "testdata/deneb.block.json")
+	if _, err := block.HashTreeRoot(nil); err == nil {
+		t.Error("expected error for a nil spec in HashTreeRoot")
+	}
+	if _, err := block.BodyRoot(nil); err == nil {
+		t.Error("expected error for a nil spec in BodyRoot")
+	}
+	if _, err := block.Header(nil); err == nil {
+		t.Error("expected error for a nil spec in Header")
+	}
+	if _, err := (&VersionedBeaconBlock{}).HashTreeRoot(&Spec{Spec: *configs.Mainnet}); err == nil {
+		t.Error("expected error for an empty block")
+	}
+}